@@ -0,0 +1,18 @@
+// Package animation provides tweens, easing functions and keyframe timelines for animating components.
+//
+// Animations are advanced by a Player which is added to the display:
+//
+//	player := animation.NewPlayer()
+//	display.Add(player)
+//
+//	circle := ui.DynamicCircle(&ui.CircleOptions{})
+//	player.Play(animation.Float(0, 64, time.Second, circle.SetRadius).
+//		SetEasing(animation.OutBounce).
+//		SetRepeat(-1).
+//		SetYoyo(true))
+//
+// Setters of components which return the component, such as those of DynamicTextComponent, are adapted with
+// TextPosition and TextColor:
+//
+//	player.Play(animation.Point(0, 0, 64, 64, time.Second, animation.TextPosition(text)))
+package animation
@@ -0,0 +1,123 @@
+package animation
+
+import (
+	"math"
+)
+
+// EasingFunc maps the linear progress of an animation between 0 and 1 to the eased progress.
+type EasingFunc func(t float64) float64
+
+// Linear progresses at a constant rate.
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad accelerates from zero velocity.
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad decelerates to zero velocity.
+func OutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// InOutQuad accelerates until halfway and then decelerates.
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// InCubic accelerates from zero velocity.
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic decelerates to zero velocity.
+func OutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// InOutCubic accelerates until halfway and then decelerates.
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// InSine accelerates along a sine curve.
+func InSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// OutSine decelerates along a sine curve.
+func OutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// InOutSine accelerates and decelerates along a sine curve.
+func InOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// InElastic winds up with an oscillation before moving.
+func InElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*(2*math.Pi)/3)
+}
+
+// OutElastic overshoots the end and oscillates into place.
+func OutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*(2*math.Pi)/3) + 1
+}
+
+// InOutElastic oscillates at both the start and the end.
+func InOutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	if t < 0.5 {
+		return -(math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*(2*math.Pi)/4.5)) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*(2*math.Pi)/4.5)/2 + 1
+}
+
+// OutBounce bounces into place at the end.
+func OutBounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	}
+	t -= 2.625 / d
+	return n*t*t + 0.984375
+}
+
+// InBounce bounces away from the start.
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
+
+// InOutBounce bounces at both the start and the end.
+func InOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - OutBounce(1-2*t)) / 2
+	}
+	return (1 + OutBounce(2*t-1)) / 2
+}
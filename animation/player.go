@@ -0,0 +1,83 @@
+package animation
+
+import (
+	"time"
+
+	"github.com/eliquious/ui"
+)
+
+// NewPlayer creates a component which plays animations as the display updates. Add the player to the display.
+func NewPlayer() *Player {
+	return &Player{}
+}
+
+// Player advances its animations on every update and drops them once they have finished.
+type Player struct {
+	animations []Animation
+	paused     bool
+}
+
+// Play starts playing the animations.
+func (p *Player) Play(a ...Animation) *Player {
+	p.animations = append(p.animations, a...)
+	return p
+}
+
+// Stop stops playing the animation without finishing it. It may be called from a completion callback.
+func (p *Player) Stop(a Animation) *Player {
+	for i := 0; i < len(p.animations); i++ {
+		if p.animations[i] == a {
+			p.animations = append(p.animations[:i], p.animations[i+1:]...)
+			break
+		}
+	}
+	return p
+}
+
+// SetPaused pauses or resumes all the animations.
+func (p *Player) SetPaused(paused bool) *Player {
+	p.paused = paused
+	return p
+}
+
+// Playing returns the number of animations which are playing.
+func (p *Player) Playing() int {
+	return len(p.animations)
+}
+
+// Advance advances all the animations by the elapsed time. Completion callbacks may play and stop animations;
+// animations played during the advance start on the next one.
+func (p *Player) Advance(dt time.Duration) {
+	current := append([]Animation(nil), p.animations...)
+	for i := 0; i < len(current); i++ {
+		// skip animations stopped by an earlier callback
+		if !p.playing(current[i]) {
+			continue
+		}
+		if current[i].Update(dt) {
+			p.Stop(current[i])
+		}
+	}
+}
+
+// playing returns true if the animation is playing.
+func (p *Player) playing(a Animation) bool {
+	for i := 0; i < len(p.animations); i++ {
+		if p.animations[i] == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Update advances the animations by the time since the last tick.
+func (p *Player) Update(ctx *ui.UpdateContext) error {
+	if p.paused {
+		return nil
+	}
+	p.Advance(ctx.Delta())
+	return nil
+}
+
+// Display is a no-op.
+func (p *Player) Display(ctx *ui.DisplayContext) {}
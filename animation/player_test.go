@@ -0,0 +1,70 @@
+package animation
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestPlayerStopFromCallback(t *testing.T) {
+	p := NewPlayer()
+	var values []float64
+	other := Float(0, 1, time.Second, func(v float64) { values = append(values, v) })
+	first := NewTween(time.Millisecond, func(float64) {}).OnComplete(func() { p.Stop(other) })
+	p.Play(first, other)
+
+	p.Advance(10 * time.Millisecond)
+	if p.Playing() != 0 {
+		t.Fatalf("expected the callback to stop the other animation; %d playing", p.Playing())
+	}
+	if len(values) != 0 {
+		t.Fatalf("expected the stopped animation to be skipped; got %v", values)
+	}
+}
+
+func TestPlayerPlayFromCallback(t *testing.T) {
+	p := NewPlayer()
+	next := NewTween(time.Second, func(float64) {})
+	first := NewTween(time.Millisecond, func(float64) {}).OnComplete(func() { p.Play(next) })
+	p.Play(first)
+
+	p.Advance(10 * time.Millisecond)
+	if p.Playing() != 1 {
+		t.Fatalf("expected the callback to play the next animation; %d playing", p.Playing())
+	}
+	if next.Done() {
+		t.Fatal("expected the next animation to start on the next advance")
+	}
+}
+
+func TestColorOvershootIsClamped(t *testing.T) {
+	easings := []EasingFunc{InElastic, OutElastic}
+	for i := 0; i < len(easings); i++ {
+		var colors []color.RGBA64
+		set := func(c color.Color) { colors = append(colors, c.(color.RGBA64)) }
+		p := NewPlayer()
+		p.Play(
+			Color(color.RGBA{0x20, 0x40, 0x80, 0x80}, color.White, time.Second, set).SetEasing(easings[i]),
+			Color(color.White, color.Black, time.Second, set).SetEasing(easings[i]),
+		)
+		for j := 0; j < 100; j++ {
+			p.Advance(10 * time.Millisecond)
+		}
+
+		for j := 0; j < len(colors); j++ {
+			c := colors[j]
+			if c.R > c.A || c.G > c.A || c.B > c.A {
+				t.Fatalf("easing %d: expected a premultiplied color; got %v", i, c)
+			}
+		}
+	}
+
+	// the overshoot past white holds at white instead of wrapping
+	var last color.RGBA64
+	p := NewPlayer()
+	p.Play(Color(color.Black, color.White, time.Second, func(c color.Color) { last = c.(color.RGBA64) }).SetEasing(OutElastic))
+	p.Advance(100 * time.Millisecond)
+	if last != (color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff}) {
+		t.Fatalf("expected the overshoot to clamp to white; got %v", last)
+	}
+}
@@ -0,0 +1,133 @@
+package animation
+
+import (
+	"time"
+)
+
+// Keyframe is a value at a time in a keyframe track. The easing is used for the segment ending at the keyframe and
+// defaults to Linear.
+type Keyframe struct {
+	At     time.Duration
+	Value  float64
+	Easing EasingFunc
+}
+
+// Keyframes creates a tween which sets a value by interpolating between the keyframes. The keyframes must be sorted by
+// time and the tween lasts until the last keyframe.
+func Keyframes(set func(v float64), frames ...Keyframe) *Tween {
+	var duration time.Duration
+	if len(frames) > 0 {
+		duration = frames[len(frames)-1].At
+	}
+	return NewTween(duration, func(t float64) {
+		if len(frames) == 0 {
+			return
+		}
+		at := time.Duration(t * float64(duration))
+		if at <= frames[0].At {
+			set(frames[0].Value)
+			return
+		}
+		for i := 1; i < len(frames); i++ {
+			if at > frames[i].At && i < len(frames)-1 {
+				continue
+			}
+			prev, next := frames[i-1], frames[i]
+			span := next.At - prev.At
+			if span <= 0 {
+				set(next.Value)
+				return
+			}
+			easing := next.Easing
+			if easing == nil {
+				easing = Linear
+			}
+			progress := float64(at-prev.At) / float64(span)
+			if progress > 1 {
+				progress = 1
+			}
+			set(lerp(prev.Value, next.Value, easing(progress)))
+			return
+		}
+		set(frames[len(frames)-1].Value)
+	})
+}
+
+// NewTimeline creates an empty timeline.
+func NewTimeline() *Timeline {
+	return &Timeline{}
+}
+
+// Timeline plays animations at offsets from its start. The timeline is done when all of its animations are done.
+type Timeline struct {
+	entries    []timelineEntry
+	elapsed    time.Duration
+	done       bool
+	onComplete func()
+}
+
+type timelineEntry struct {
+	offset    time.Duration
+	animation Animation
+}
+
+// Add adds an animation which starts at the offset.
+func (tl *Timeline) Add(offset time.Duration, a Animation) *Timeline {
+	tl.entries = append(tl.entries, timelineEntry{offset, a})
+	return tl
+}
+
+// OnComplete sets the function called when the timeline finishes.
+func (tl *Timeline) OnComplete(fn func()) *Timeline {
+	tl.onComplete = fn
+	return tl
+}
+
+// Update advances the animations which have started and returns true once all have finished.
+func (tl *Timeline) Update(dt time.Duration) bool {
+	if tl.done {
+		return true
+	}
+	start := tl.elapsed
+	tl.elapsed += dt
+
+	done := true
+	for i := 0; i < len(tl.entries); i++ {
+		e := tl.entries[i]
+		if e.offset > tl.elapsed {
+			done = false
+			continue
+		}
+
+		// only advance by the time since the animation started
+		step := dt
+		if e.offset > start {
+			step = tl.elapsed - e.offset
+		}
+		if !e.animation.Update(step) {
+			done = false
+		}
+	}
+
+	if done {
+		tl.done = true
+		if tl.onComplete != nil {
+			tl.onComplete()
+		}
+	}
+	return done
+}
+
+// Done returns true if the timeline has finished.
+func (tl *Timeline) Done() bool {
+	return tl.done
+}
+
+// Reset restarts the timeline and all of its animations.
+func (tl *Timeline) Reset() {
+	tl.elapsed = 0
+	tl.done = false
+	for i := 0; i < len(tl.entries); i++ {
+		tl.entries[i].animation.Reset()
+	}
+}
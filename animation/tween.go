@@ -0,0 +1,183 @@
+package animation
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/eliquious/ui"
+)
+
+// Animation is advanced by the elapsed time until it is done.
+type Animation interface {
+
+	// Update advances the animation and returns true once it has finished.
+	Update(dt time.Duration) bool
+
+	// Done returns true if the animation has finished.
+	Done() bool
+
+	// Reset restarts the animation from the beginning.
+	Reset()
+}
+
+// Float creates a tween which sets a value from the start to the end over the duration.
+func Float(from, to float64, duration time.Duration, set func(v float64)) *Tween {
+	return NewTween(duration, func(t float64) {
+		set(lerp(from, to, t))
+	})
+}
+
+// Point creates a tween which moves a point from the start to the end over the duration.
+func Point(fromX, fromY, toX, toY float64, duration time.Duration, set func(x, y float64)) *Tween {
+	return NewTween(duration, func(t float64) {
+		set(lerp(fromX, toX, t), lerp(fromY, toY, t))
+	})
+}
+
+// TextPosition returns a setter for Point which moves the text component.
+func TextPosition(text *ui.DynamicTextComponent) func(x, y float64) {
+	return func(x, y float64) {
+		text.SetPosition(x, y)
+	}
+}
+
+// TextColor returns a setter for Color which changes the color of the text component.
+func TextColor(text *ui.DynamicTextComponent) func(c color.Color) {
+	return func(c color.Color) {
+		text.SetTextColor(c)
+	}
+}
+
+// Color creates a tween which blends a color from the start to the end over the duration. Easings which overshoot are
+// clamped to valid colors.
+func Color(from, to color.Color, duration time.Duration, set func(c color.Color)) *Tween {
+	r0, g0, b0, a0 := from.RGBA()
+	r1, g1, b1, a1 := to.RGBA()
+	return NewTween(duration, func(t float64) {
+		// the colors are premultiplied so no channel may exceed the alpha
+		a := channel(a0, a1, t, 0xffff)
+		set(color.RGBA64{
+			R: channel(r0, r1, t, a),
+			G: channel(g0, g1, t, a),
+			B: channel(b0, b1, t, a),
+			A: a,
+		})
+	})
+}
+
+// NewTween creates a tween which calls the function with the eased progress between 0 and 1 over the duration. The
+// progress may leave that range for easings which overshoot, such as OutElastic.
+func NewTween(duration time.Duration, apply func(t float64)) *Tween {
+	return &Tween{duration: duration, apply: apply, easing: Linear}
+}
+
+// Tween interpolates a value over time. The tween waits for the delay, then plays once plus the number of repeats.
+// Yoyo tweens play backwards on every other repeat.
+type Tween struct {
+	duration   time.Duration
+	delay      time.Duration
+	easing     EasingFunc
+	repeat     int
+	yoyo       bool
+	apply      func(t float64)
+	onComplete func()
+
+	elapsed time.Duration
+	done    bool
+}
+
+// SetEasing sets the easing function. The default is Linear.
+func (t *Tween) SetEasing(e EasingFunc) *Tween {
+	t.easing = e
+	return t
+}
+
+// SetDelay sets the time to wait before the tween starts.
+func (t *Tween) SetDelay(d time.Duration) *Tween {
+	t.delay = d
+	return t
+}
+
+// SetRepeat sets the number of times the tween repeats after the first play. A negative count repeats forever.
+func (t *Tween) SetRepeat(n int) *Tween {
+	t.repeat = n
+	return t
+}
+
+// SetYoyo sets whether the tween plays backwards on every other repeat.
+func (t *Tween) SetYoyo(yoyo bool) *Tween {
+	t.yoyo = yoyo
+	return t
+}
+
+// OnComplete sets the function called when the tween finishes.
+func (t *Tween) OnComplete(fn func()) *Tween {
+	t.onComplete = fn
+	return t
+}
+
+// Update advances the tween and returns true once it has finished.
+func (t *Tween) Update(dt time.Duration) bool {
+	if t.done {
+		return true
+	}
+	t.elapsed += dt
+	run := t.elapsed - t.delay
+	if run < 0 {
+		return false
+	}
+
+	// instant tweens finish immediately
+	if t.duration <= 0 {
+		t.finish()
+		return true
+	}
+
+	plays := int(run / t.duration)
+	if t.repeat >= 0 && plays > t.repeat {
+		t.finish()
+		return true
+	}
+
+	progress := float64(run-time.Duration(plays)*t.duration) / float64(t.duration)
+	if t.yoyo && plays%2 == 1 {
+		progress = 1 - progress
+	}
+	t.apply(t.easing(progress))
+	return false
+}
+
+// finish applies the final value and calls the completion function.
+func (t *Tween) finish() {
+	t.done = true
+	if t.yoyo && t.repeat%2 == 1 {
+		t.apply(t.easing(0))
+	} else {
+		t.apply(t.easing(1))
+	}
+	if t.onComplete != nil {
+		t.onComplete()
+	}
+}
+
+// Done returns true if the tween has finished.
+func (t *Tween) Done() bool {
+	return t.done
+}
+
+// Reset restarts the tween from the beginning including the delay.
+func (t *Tween) Reset() {
+	t.elapsed = 0
+	t.done = false
+}
+
+// lerp interpolates linearly between the values.
+func lerp(from, to, t float64) float64 {
+	return from + (to-from)*t
+}
+
+// channel interpolates a color channel and clamps it between 0 and the maximum.
+func channel(from, to uint32, t float64, max uint16) uint16 {
+	return uint16(math.Max(0, math.Min(float64(max), lerp(float64(from), float64(to), t))))
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CaptureFormat enumerates the output formats for frame capture.
+type CaptureFormat int
+
+// These are the available capture formats.
+const (
+
+	// CapturePNG writes every captured frame to a numbered PNG file.
+	CapturePNG CaptureFormat = iota
+
+	// CaptureGIF writes the captured frames to an animated GIF when the capture stops.
+	CaptureGIF
+)
+
+// DefaultMaxCaptureFrames is the number of frames captured when MaxFrames is zero, which is 10 seconds at 60 TPS.
+// Frames of a GIF are held in memory until the capture stops.
+const DefaultMaxCaptureFrames = 600
+
+// captureQueue is the number of frames waiting to be encoded before the game loop waits for the encoder.
+const captureQueue = 8
+
+// CaptureOptions stores the options for capturing the screen. A frame is captured every Every frames until MaxFrames
+// have been captured or the capture is stopped. Zero Every captures every frame and zero MaxFrames captures up to
+// DefaultMaxCaptureFrames. Files are written to Dir, which defaults to the working directory, with the name Prefix,
+// which defaults to "capture", followed by the time the capture started, so later captures do not overwrite earlier
+// ones. A zero HotKey uses F12 to start and stop the capture.
+type CaptureOptions struct {
+	Format    CaptureFormat
+	Every     int
+	MaxFrames int
+	Dir       string
+	Prefix    string
+	HotKey    ebiten.Key
+}
+
+// frameCapture captures the screen while running. Frames are encoded in order by a goroutine so the game loop only
+// copies the pixels.
+type frameCapture struct {
+	opts  CaptureOptions
+	name  string
+	frame int
+	count int
+	anim  *gif.GIF
+
+	frames chan *image.RGBA
+	done   chan error
+}
+
+// newFrameCapture creates a frame capture with the defaults applied and starts the encoder.
+func newFrameCapture(opts *CaptureOptions) *frameCapture {
+	o := *opts
+	if o.Every < 1 {
+		o.Every = 1
+	}
+	if o.MaxFrames <= 0 {
+		o.MaxFrames = DefaultMaxCaptureFrames
+	}
+	if o.Prefix == "" {
+		o.Prefix = "capture"
+	}
+	if o.HotKey == 0 {
+		o.HotKey = ebiten.KeyF12
+	}
+	c := &frameCapture{
+		opts:   o,
+		name:   captureName(o.Dir, o.Prefix),
+		frames: make(chan *image.RGBA, captureQueue),
+		done:   make(chan error, 1),
+	}
+	if o.Format == CaptureGIF {
+		c.anim = &gif.GIF{}
+	}
+	go c.encode()
+	return c
+}
+
+// captureName returns the prefix followed by the current time. A number is added if a capture with the name exists.
+func captureName(dir, prefix string) string {
+	base := prefix + "-" + time.Now().Format("20060102-150405")
+	name := base
+	for n := 2; ; n++ {
+		_, errPNG := os.Stat(filepath.Join(dir, name+"-000001.png"))
+		_, errGIF := os.Stat(filepath.Join(dir, name+".gif"))
+		if os.IsNotExist(errPNG) && os.IsNotExist(errGIF) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// StartCapture starts capturing the screen. A capture which is already running is stopped first.
+func (d *Display) StartCapture(opts *CaptureOptions) error {
+	if d.capture != nil {
+		if err := d.StopCapture(); err != nil {
+			return err
+		}
+	}
+	if opts.Dir != "" {
+		if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+			return err
+		}
+	}
+	d.capture = newFrameCapture(opts)
+	return nil
+}
+
+// StopCapture stops capturing the screen and waits for the files to be written.
+func (d *Display) StopCapture() error {
+	c := d.capture
+	if c == nil {
+		return nil
+	}
+	d.capture = nil
+	return c.finish()
+}
+
+// stopCaptureOnExit finishes a running capture when the display exits so the frames are not lost.
+func (d *Display) stopCaptureOnExit() {
+	if err := d.StopCapture(); err != nil {
+		log.Printf("frame capture failed: %s", err)
+	}
+}
+
+// Capturing returns true while the screen is being captured.
+func (d *Display) Capturing() bool {
+	return d.capture != nil
+}
+
+// Screenshot saves the next frame to a PNG file named with the prefix and the current time.
+func (d *Display) Screenshot(dir, prefix string) error {
+	return d.StartCapture(&CaptureOptions{Format: CapturePNG, MaxFrames: 1, Dir: dir, Prefix: prefix})
+}
+
+// updateCapture starts or stops the capture when the hot key is pressed.
+func (d *Display) updateCapture() {
+	opts := d.settings.Capture
+	if opts == nil {
+		return
+	}
+	key := opts.HotKey
+	if key == 0 {
+		key = ebiten.KeyF12
+	}
+	if !d.input.IsKeyJustPressed(key) {
+		return
+	}
+
+	var err error
+	if d.capture != nil {
+		err = d.StopCapture()
+	} else {
+		err = d.StartCapture(opts)
+	}
+	if err != nil {
+		log.Printf("frame capture failed: %s", err)
+	}
+}
+
+// captureFrame captures the screen if the capture is due and stops the capture after the last frame.
+func (d *Display) captureFrame(screen *ebiten.Image) {
+	c := d.capture
+	if c == nil {
+		return
+	}
+	c.frame++
+	if (c.frame-1)%c.opts.Every != 0 {
+		return
+	}
+	c.add(readPixels(screen))
+
+	if c.count >= c.opts.MaxFrames {
+		if err := d.StopCapture(); err != nil {
+			log.Printf("frame capture failed: %s", err)
+		}
+	}
+}
+
+// add queues a frame for the encoder. The game loop waits when the encoder falls behind by more than the queue.
+func (c *frameCapture) add(img *image.RGBA) {
+	c.count++
+	c.frames <- img
+}
+
+// encode writes the PNG files or dithers the GIF frames as they are queued and writes the GIF once the queue is
+// closed. The first error is sent to done once all the frames have been handled.
+func (c *frameCapture) encode() {
+	var err error
+	count := 0
+	for img := range c.frames {
+		count++
+		if err != nil {
+			continue
+		}
+		if c.anim != nil {
+			paletted := image.NewPaletted(img.Bounds(), palette.Plan9)
+			draw.FloydSteinberg.Draw(paletted, img.Bounds(), img, image.Point{})
+			c.anim.Image = append(c.anim.Image, paletted)
+			c.anim.Delay = append(c.anim.Delay, c.delay())
+			continue
+		}
+		err = SavePNG(filepath.Join(c.opts.Dir, fmt.Sprintf("%s-%06d.png", c.name, count)), img)
+	}
+	if err == nil && c.anim != nil && len(c.anim.Image) > 0 {
+		err = c.writeGIF()
+	}
+	c.done <- err
+}
+
+// delay returns the delay between GIF frames in hundredths of a second.
+func (c *frameCapture) delay() int {
+	tps := ebiten.MaxTPS()
+	if tps <= 0 {
+		tps = 60
+	}
+	delay := 100 * c.opts.Every / tps
+	if delay < 1 {
+		delay = 1
+	}
+	return delay
+}
+
+// finish waits for the queued frames to be encoded and the files to be written. It returns the first error.
+func (c *frameCapture) finish() error {
+	close(c.frames)
+	return <-c.done
+}
+
+// writeGIF writes the animated GIF.
+func (c *frameCapture) writeGIF() error {
+	f, err := os.Create(filepath.Join(c.opts.Dir, c.name+".gif"))
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, c.anim); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SavePNG writes the image to a PNG file, creating the directory if needed.
+func SavePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// readPixels copies the pixels of the image into memory. It must be called from the game loop. Ebiten loads the pixels
+// from the GPU once on the first call to At and reads the rest from memory, so the pixels are copied straight into the
+// image without converting colors.
+func readPixels(src *ebiten.Image) *image.RGBA {
+	w, h := src.Size()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		row := img.Pix[y*img.Stride:]
+		for x := 0; x < w; x++ {
+			c := src.At(x, y).(color.RGBA)
+			row[4*x], row[4*x+1], row[4*x+2], row[4*x+3] = c.R, c.G, c.B, c.A
+		}
+	}
+	return img
+}
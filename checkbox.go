@@ -0,0 +1,308 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// These are the default sizes of the toggle widgets in pixels.
+const (
+	DefaultToggleSize = 16
+	DefaultToggleGap  = 8
+)
+
+// CheckboxOptions contains the options for a checkbox. Unset fonts and colors follow the theme and a zero size or gap
+// uses the defaults.
+type CheckboxOptions struct {
+	Label     string
+	Font      string
+	FontSize  float64
+	TextColor color.Color
+	Size      int
+	Gap       int
+	Checked   bool
+	Disabled  bool
+
+	// OnChange is called whenever the checkbox is toggled by the user.
+	OnChange func(checked bool)
+}
+
+// Checkbox creates a themed checkbox with a label at the position. It is toggled by clicking the box or the label and
+// by pressing Space or Enter while focused.
+func Checkbox(x, y int, opts *CheckboxOptions) *CheckboxComponent {
+	c := &CheckboxComponent{opts: opts, checked: opts.Checked}
+	c.init(x, y, opts.Size, opts.Size, opts.Gap, opts.Disabled, &TextOptions{
+		Font:      opts.Font,
+		FontSize:  opts.FontSize,
+		TextColor: opts.TextColor,
+	}, opts.Label)
+	return c
+}
+
+// CheckboxComponent is a checkbox with a label.
+type CheckboxComponent struct {
+	toggleControl
+	opts    *CheckboxOptions
+	checked bool
+}
+
+// Checked returns true if the checkbox is checked.
+func (c *CheckboxComponent) Checked() bool {
+	return c.checked
+}
+
+// SetChecked checks or unchecks the checkbox. OnChange is not called.
+func (c *CheckboxComponent) SetChecked(checked bool) *CheckboxComponent {
+	c.checked = checked
+	return c
+}
+
+// Toggle toggles the checkbox and calls OnChange.
+func (c *CheckboxComponent) Toggle() {
+	c.checked = !c.checked
+	if c.opts.OnChange != nil {
+		c.opts.OnChange(c.checked)
+	}
+}
+
+// OnMouseEvent toggles the checkbox when it is clicked.
+func (c *CheckboxComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if c.click(x, y, evt) {
+		c.Toggle()
+	}
+}
+
+// OnKeyEvent toggles the checkbox when Space or Enter is pressed.
+func (c *CheckboxComponent) OnKeyEvent(evt KeyEvent) {
+	if c.activated(evt) {
+		c.Toggle()
+	}
+}
+
+// Display renders the box, the check mark and the label.
+func (c *CheckboxComponent) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	state := c.state(theme)
+	box := c.control()
+
+	fill, mark := state.Background, theme.Palette.OnPrimary
+	if c.checked {
+		fill = theme.Palette.Primary
+		if c.disabled {
+			fill, mark = theme.Widget.Disabled.Foreground, theme.Widget.Disabled.Background
+		}
+	}
+	drawBox(ctx, box, fill, state.Border, theme.BorderWidth)
+
+	// check mark
+	if c.checked {
+		s := float64(box.Dx())
+		x, y := float64(box.Min.X), float64(box.Min.Y)
+		stroke := Stroke{mark, int(s/8) + 1}
+		c.drawLine(ctx, x+s*0.22, y+s*0.52, x+s*0.42, y+s*0.72, stroke)
+		c.drawLine(ctx, x+s*0.42, y+s*0.72, x+s*0.78, y+s*0.30, stroke)
+	}
+	c.displayLabel(ctx)
+}
+
+func (c *CheckboxComponent) drawLine(ctx *DisplayContext, x0, y0, x1, y1 float64, stroke Stroke) {
+	vs, is := LineVertices(x0, y0, x1, y1, stroke)
+	ctx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{Filter: ebiten.FilterLinear})
+}
+
+// toggleControl is the state shared by the toggle widgets, which draw a control followed by a label. The widgets
+// handle the mouse and keyboard through it and only decide what activation does.
+type toggleControl struct {
+	x, y          int
+	width, height int
+	gap           int
+	label         *DynamicTextComponent
+	textColor     color.Color
+	hasLabel      bool
+
+	disabled bool
+	focused  bool
+	hover    bool
+	pressed  bool
+}
+
+// init sets the position, the size of the control and the label.
+func (c *toggleControl) init(x, y, width, height, gap int, disabled bool, opts *TextOptions, label string) {
+	if height <= 0 {
+		height = DefaultToggleSize
+	}
+	if width <= 0 {
+		width = height
+	}
+	if gap <= 0 {
+		gap = DefaultToggleGap
+	}
+	c.x, c.y = x, y
+	c.width, c.height = width, height
+	c.gap = gap
+	c.disabled = disabled
+	c.textColor = opts.TextColor
+	c.hasLabel = label != ""
+	c.label = DynamicText(opts).SetText(label)
+}
+
+// SetLabel changes the label.
+func (c *toggleControl) SetLabel(label string) {
+	c.hasLabel = label != ""
+	c.label.SetText(label)
+}
+
+// Disabled returns true if the widget ignores input.
+func (c *toggleControl) Disabled() bool {
+	return c.disabled
+}
+
+// SetDisabled enables or disables the widget. A disabled widget is drawn in the disabled colors of the theme and
+// ignores the mouse and keyboard.
+func (c *toggleControl) SetDisabled(disabled bool) {
+	c.disabled = disabled
+	if disabled {
+		c.pressed = false
+	}
+}
+
+// Focused returns true if the widget has keyboard focus.
+func (c *toggleControl) Focused() bool {
+	return c.focused
+}
+
+// OnFocus draws the focus border.
+func (c *toggleControl) OnFocus() {
+	c.focused = true
+}
+
+// OnBlur removes the focus border.
+func (c *toggleControl) OnBlur() {
+	c.focused = false
+}
+
+// SetPosition moves the widget.
+func (c *toggleControl) SetPosition(x, y int) {
+	c.x, c.y = x, y
+}
+
+// Contains returns true if the point is within the control or the label.
+func (c *toggleControl) Contains(x, y int) bool {
+	return image.Pt(x, y).In(c.bounds())
+}
+
+// OnMouseMove highlights the widget while the mouse is over it.
+func (c *toggleControl) OnMouseMove(x, y int) {
+	c.hover = c.Contains(x, y)
+}
+
+// Update updates the label.
+func (c *toggleControl) Update(ctx *UpdateContext) error {
+	if c.disabled {
+		c.label.SetTextColor(ThemeFromContext(ctx.Context()).Widget.Disabled.Foreground)
+	} else {
+		c.label.SetTextColor(c.textColor)
+	}
+	return c.label.Update(ctx)
+}
+
+// Measure returns the size of the control and the label.
+func (c *toggleControl) Measure(cons Constraints) SizeHints {
+	return BoundsSizeHints(c.bounds())
+}
+
+// click returns true when the left button is released over the widget after being pressed on it.
+func (c *toggleControl) click(x, y int, evt MouseEvent) bool {
+	if c.disabled || evt.Button != ebiten.MouseButtonLeft {
+		return false
+	}
+	switch evt.EventType {
+	case MousePressEvent:
+		c.pressed = c.Contains(x, y)
+	case MouseReleaseEvent:
+		clicked := c.pressed && c.Contains(x, y)
+		c.pressed = false
+		return clicked
+	}
+	return false
+}
+
+// activated returns true when Space or Enter is pressed.
+func (c *toggleControl) activated(evt KeyEvent) bool {
+	if c.disabled || evt.EventType != KeyPressEvent {
+		return false
+	}
+	return evt.Key == ebiten.KeySpace || evt.Key == ebiten.KeyEnter || evt.Key == ebiten.KeyKPEnter
+}
+
+// state returns the widget colors of the theme for the current state.
+func (c *toggleControl) state(t *Theme) StateStyle {
+	switch {
+	case c.disabled:
+		return t.Widget.Disabled
+	case c.pressed:
+		return t.Widget.Pressed
+	case c.focused:
+		return t.Widget.Focused
+	case c.hover:
+		return t.Widget.Hover
+	}
+	return t.Widget.Normal
+}
+
+// control returns the rectangle of the control, which is centered vertically on the label.
+func (c *toggleControl) control() image.Rectangle {
+	dy := 0
+	if h := c.labelHeight(); h > c.height {
+		dy = (h - c.height) / 2
+	}
+	return Rect(c.x, c.y+dy, c.width, c.height)
+}
+
+// bounds returns the rectangle of the control and the label.
+func (c *toggleControl) bounds() image.Rectangle {
+	r := Rect(c.x, c.y, c.width, c.height)
+	if c.hasLabel {
+		r.Max.X += c.gap + c.label.TextBounds().Dx()
+		if h := c.labelHeight(); h > c.height {
+			r.Max.Y = c.y + h
+		}
+	}
+	return r
+}
+
+// labelHeight returns the line height of the label font.
+func (c *toggleControl) labelHeight() int {
+	if !c.hasLabel || c.label.FontFace() == nil {
+		return 0
+	}
+	m := c.label.FontFace().Metrics()
+	return m.Ascent.Ceil() + m.Descent.Ceil()
+}
+
+// displayLabel renders the label after the control.
+func (c *toggleControl) displayLabel(ctx *DisplayContext) {
+	if !c.hasLabel {
+		return
+	}
+	m := c.label.FontFace().Metrics()
+	box := c.control()
+	baseline := box.Min.Y + (box.Dy()+m.Ascent.Ceil()-m.Descent.Ceil())/2
+	drawTextAt(ctx, c.label, box.Max.X+c.gap, baseline)
+}
+
+// drawBox fills the rectangle with a border of the width.
+func drawBox(ctx *DisplayContext, r image.Rectangle, fill, border color.Color, width int) {
+	op := &ebiten.DrawTrianglesOptions{}
+	if width > 0 && border != nil {
+		vs, is := RectVertices(r.Min.X, r.Min.Y, r.Max.X, r.Max.Y, border)
+		ctx.DrawTriangles(vs, is, op)
+		r = r.Inset(width)
+	}
+	if fill != nil {
+		vs, is := RectVertices(r.Min.X, r.Min.Y, r.Max.X, r.Max.Y, fill)
+		ctx.DrawTriangles(vs, is, op)
+	}
+}
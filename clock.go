@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MaxClockDelta is the longest time a real clock advances in one tick. Longer gaps, such as while the window is being
+// dragged, are clamped so time-based motion does not jump.
+const MaxClockDelta = 250 * time.Millisecond
+
+// NewClock creates a clock which follows the wall clock.
+func NewClock() *Clock {
+	return &Clock{scale: 1, now: time.Now}
+}
+
+// NewVirtualClock creates a clock which advances by the step on every tick regardless of the wall clock. A zero step
+// advances by the duration of a tick at the maximum TPS.
+func NewVirtualClock(step time.Duration) *Clock {
+	return &Clock{scale: 1, step: step, virtual: true}
+}
+
+// newStepClock creates a virtual clock which advances by the steps, one per tick, and then by the duration of a tick.
+func newStepClock(steps []time.Duration) *Clock {
+	return &Clock{scale: 1, steps: append([]time.Duration(nil), steps...), virtual: true}
+}
+
+// Clock is the game clock owned by a display. It counts frames and measures the time between ticks. The game time may
+// be paused and scaled without affecting the frame count.
+type Clock struct {
+	frame   int64
+	elapsed time.Duration
+	delta   time.Duration
+	scale   float64
+	paused  bool
+
+	now     func() time.Time
+	last    time.Time
+	virtual bool
+	step    time.Duration
+	steps   []time.Duration
+	pending time.Duration
+
+	// tick is the time since the last tick before it was paused or scaled
+	tick time.Duration
+}
+
+// Tick advances the clock by one frame. It is called by the display at the start of every update.
+func (c *Clock) Tick() {
+	c.frame++
+
+	var delta time.Duration
+	if c.virtual {
+		delta = c.step
+		if len(c.steps) > 0 {
+			delta = c.steps[0]
+			c.steps = c.steps[1:]
+		} else if delta <= 0 {
+			delta = tickDuration()
+		}
+		delta += c.pending
+		c.pending = 0
+	} else {
+		now := c.now()
+		if c.last.IsZero() {
+			delta = tickDuration()
+		} else {
+			delta = now.Sub(c.last)
+		}
+		c.last = now
+		if delta > MaxClockDelta {
+			delta = MaxClockDelta
+		}
+	}
+
+	c.tick = delta
+	if c.paused {
+		delta = 0
+	}
+	c.delta = time.Duration(float64(delta) * c.scale)
+	c.elapsed += c.delta
+}
+
+// Advance adds time to the next tick of a virtual clock. It has no effect on a real clock.
+func (c *Clock) Advance(d time.Duration) {
+	c.pending += d
+}
+
+// Frame returns the number of ticks.
+func (c *Clock) Frame() int64 {
+	return c.frame
+}
+
+// Elapsed returns the game time since the clock started.
+func (c *Clock) Elapsed() time.Duration {
+	return c.elapsed
+}
+
+// Delta returns the game time since the last tick.
+func (c *Clock) Delta() time.Duration {
+	return c.delta
+}
+
+// SetScale sets the rate of the game time. A scale of 0.5 runs at half speed.
+func (c *Clock) SetScale(scale float64) {
+	if scale < 0 {
+		scale = 0
+	}
+	c.scale = scale
+}
+
+// Scale returns the rate of the game time.
+func (c *Clock) Scale() float64 {
+	return c.scale
+}
+
+// Pause stops the game time.
+func (c *Clock) Pause() {
+	c.paused = true
+}
+
+// Resume restarts the game time.
+func (c *Clock) Resume() {
+	c.paused = false
+}
+
+// Paused returns true if the game time is stopped.
+func (c *Clock) Paused() bool {
+	return c.paused
+}
+
+// tickDuration returns the duration of a tick at the maximum TPS.
+func tickDuration() time.Duration {
+	tps := ebiten.MaxTPS()
+	if tps <= 0 {
+		tps = 60
+	}
+	return time.Second / time.Duration(tps)
+}
@@ -0,0 +1,444 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ComboBoxOptions contains the options for a combo box. Unset fonts and colors follow the theme. The list shows up to
+// MaxVisible items, which defaults to 8, of ItemHeight pixels, which defaults to the height of the combo box and is at
+// least 1. The list is shown in the overlay Layer, which defaults to the menu layer so combo boxes within modal dialogs
+// open their list above the dialog. Nil options are empty options.
+type ComboBoxOptions struct {
+	Font        string
+	FontSize    float64
+	TextColor   color.Color
+	Placeholder string
+	Text        string
+	MaxVisible  int
+	ItemHeight  int
+	MaxLength   int
+	Layer       string
+
+	// OnChange is called whenever the text is edited.
+	OnChange func(text string)
+
+	// OnSelect is called whenever the user chooses an item from the list.
+	OnSelect func(index int, item string)
+
+	// OnSubmit is called when Enter is pressed without a highlighted item.
+	OnSubmit func(text string)
+}
+
+// ComboBox creates a themed combo box, which is a text input with a list of the items containing the text. Editing the
+// text filters the list and opens it in an overlay layer of the display. Clicking the arrow opens or closes the list and
+// clicking an item replaces the text with the item.
+//
+// While focused, the keys edit the text except for the arrow keys, Page Up and Page Down, which move the highlight in
+// the list, and Enter, which chooses the highlighted item. The combo box must be added to a display so the list can be
+// shown in its overlay layers.
+func ComboBox(r image.Rectangle, items []string, opts *ComboBoxOptions) *ComboBoxComponent {
+	if opts == nil {
+		opts = &ComboBoxOptions{}
+	}
+	maxVisible := opts.MaxVisible
+	if maxVisible <= 0 {
+		maxVisible = DefaultDropdownMaxVisible
+	}
+	itemHeight := opts.ItemHeight
+	if itemHeight <= 0 {
+		itemHeight = r.Dy()
+	}
+	if itemHeight < 1 {
+		itemHeight = 1
+	}
+
+	c := &ComboBoxComponent{
+		r:           r,
+		opts:        opts,
+		items:       items,
+		highlighted: -1,
+		maxVisible:  maxVisible,
+		itemHeight:  itemHeight,
+		texts:       make([]*DynamicTextComponent, len(items)),
+	}
+	textOpts := &TextOptions{Font: opts.Font, FontSize: opts.FontSize, TextColor: opts.TextColor}
+	for i := 0; i < len(items); i++ {
+		c.texts[i] = DynamicText(textOpts).SetText(items[i])
+	}
+	c.input = c.newInput(DefaultTheme)
+	c.input.SetText(opts.Text)
+	c.filter()
+	c.list = &comboList{c}
+	return c
+}
+
+// ComboBoxComponent is a text input with a filtered list of items to choose from.
+type ComboBoxComponent struct {
+	r     image.Rectangle
+	opts  *ComboBoxOptions
+	items []string
+	input *TextInputComponent
+	theme *Theme
+
+	matches     []int
+	highlighted int
+	scroll      int
+	wheel       float64
+	maxVisible  int
+	itemHeight  int
+
+	open    bool
+	focused bool
+	hover   bool
+	pressed bool
+
+	texts []*DynamicTextComponent
+	list  *comboList
+	popup *Popup
+}
+
+// Text returns the text of the combo box.
+func (c *ComboBoxComponent) Text() string {
+	return c.input.Text()
+}
+
+// SetText replaces the text and filters the list. OnChange is not called.
+func (c *ComboBoxComponent) SetText(s string) *ComboBoxComponent {
+	c.input.SetText(s)
+	c.filter()
+	return c
+}
+
+// Matches returns the indices of the items shown in the list, which are the items containing the text ignoring case.
+func (c *ComboBoxComponent) Matches() []int {
+	return c.matches
+}
+
+// Select replaces the text with the item, closes the list and calls OnSelect.
+func (c *ComboBoxComponent) Select(index int) {
+	if index < 0 || index >= len(c.items) {
+		return
+	}
+	c.SetText(c.items[index])
+	c.open = false
+	if c.opts.OnSelect != nil {
+		c.opts.OnSelect(index, c.items[index])
+	}
+}
+
+// Open returns true while the list is shown.
+func (c *ComboBoxComponent) Open() bool {
+	return c.open
+}
+
+// SetOpen shows or hides the list. The list is not shown without matching items. It is shown or closed during the next
+// update.
+func (c *ComboBoxComponent) SetOpen(open bool) {
+	if open && !c.open {
+		c.highlighted = -1
+		c.scroll = 0
+	}
+	c.open = open && len(c.matches) > 0
+}
+
+// Focused returns true if the combo box has keyboard focus.
+func (c *ComboBoxComponent) Focused() bool {
+	return c.focused
+}
+
+// OnFocus shows the caret.
+func (c *ComboBoxComponent) OnFocus() {
+	c.focused = true
+	c.input.OnFocus()
+}
+
+// OnBlur hides the caret and closes the list.
+func (c *ComboBoxComponent) OnBlur() {
+	c.focused = false
+	c.open = false
+	c.input.OnBlur()
+}
+
+// Contains returns true if the point is within the combo box.
+func (c *ComboBoxComponent) Contains(x, y int) bool {
+	return image.Pt(x, y).In(c.r)
+}
+
+// OnMouseEvent opens or closes the list when the arrow is clicked and edits the text otherwise.
+func (c *ComboBoxComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if evt.Button == ebiten.MouseButtonLeft {
+		switch evt.EventType {
+		case MousePressEvent:
+			c.pressed = image.Pt(x, y).In(c.arrow())
+		case MouseReleaseEvent:
+			if c.pressed && image.Pt(x, y).In(c.arrow()) {
+				if !c.open {
+					c.filter()
+				}
+				c.SetOpen(!c.open)
+			}
+			c.pressed = false
+		}
+	}
+	if !c.pressed {
+		c.input.OnMouseEvent(x, y, evt)
+	}
+}
+
+// OnMouseMove highlights the combo box while the mouse is over it and extends the selection of the text.
+func (c *ComboBoxComponent) OnMouseMove(x, y int) {
+	c.hover = c.Contains(x, y)
+	c.input.OnMouseMove(x, y)
+}
+
+// OnKeyEvent moves the highlight in the list and chooses from it. The other keys edit the text.
+func (c *ComboBoxComponent) OnKeyEvent(evt KeyEvent) {
+	if evt.EventType == KeyReleaseEvent {
+		return
+	}
+	switch evt.Key {
+	case ebiten.KeyEnter, ebiten.KeyKPEnter:
+		if evt.EventType != KeyPressEvent {
+			return
+		}
+		if c.open && c.highlighted >= 0 {
+			c.Select(c.matches[c.highlighted])
+			return
+		}
+		c.open = false
+		if c.opts.OnSubmit != nil {
+			c.opts.OnSubmit(c.Text())
+		}
+	case ebiten.KeyDown:
+		c.highlight(c.highlighted + 1)
+	case ebiten.KeyUp:
+		c.highlight(c.highlighted - 1)
+	case ebiten.KeyPageDown:
+		c.highlight(c.highlighted + c.maxVisible)
+	case ebiten.KeyPageUp:
+		c.highlight(c.highlighted - c.maxVisible)
+	default:
+		c.input.OnKeyEvent(evt)
+	}
+}
+
+// OnTextInput inserts the typed characters at the caret.
+func (c *ComboBoxComponent) OnTextInput(chars []rune) {
+	c.input.OnTextInput(chars)
+}
+
+// Update updates the text and shows or hides the list in the overlay.
+func (c *ComboBoxComponent) Update(ctx *UpdateContext) error {
+	theme := ThemeFromContext(ctx.Context())
+	if theme != c.theme {
+		// the text is kept by moving the editor to the input built for the spacing of the theme
+		input := c.newInput(theme)
+		input.editor = c.input.editor
+		input.focused = c.input.focused
+		c.input = input
+	}
+	if err := c.input.Update(ctx); err != nil {
+		return err
+	}
+
+	// the list is placed below the combo box in screen coordinates, or above it near the bottom of the screen
+	anchor := c.r
+	if n := ctx.Node(); n != nil {
+		x, y := n.ScreenPosition()
+		anchor = anchor.Add(image.Pt(int(x), int(y)))
+	}
+	size := image.Pt(c.r.Dx(), c.visible()*c.itemHeight)
+	if c.open && c.popup == nil {
+		if ctx.Overlays() == nil {
+			c.open = false
+			return nil
+		}
+		c.popup = ctx.Overlays().Show(c.list, &PopupOptions{
+			Layer:   listLayer(c.opts.Layer),
+			Anchor:  anchor,
+			Size:    size,
+			Dismiss: true,
+			OnClose: func() {
+				c.open = false
+				c.popup = nil
+			},
+		})
+	} else if c.open {
+		c.popup.SetAnchor(anchor)
+		c.popup.SetSize(size)
+	} else if c.popup != nil {
+		c.popup.Close()
+	}
+
+	if c.open {
+		for i := c.scroll; i < c.scroll+c.visible(); i++ {
+			if err := c.texts[c.matches[i]].Update(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Display renders the box, the text and the arrow.
+func (c *ComboBoxComponent) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	state := theme.Widget.Normal
+	switch {
+	case c.focused || c.open:
+		state = theme.Widget.Focused
+	case c.hover:
+		state = theme.Widget.Hover
+	}
+	drawBox(ctx, c.r, state.Background, state.Border, theme.BorderWidth)
+	c.input.Display(ctx)
+
+	// arrow
+	size := float32(c.r.Dy()) / 4
+	cx, cy := float32(c.r.Max.X-theme.Spacing.M)-size, float32(c.r.Min.Y)+float32(c.r.Dy())/2
+	if c.open {
+		drawTriangle(ctx, cx-size, cy+size/2, cx+size, cy+size/2, cx, cy-size/2, state.Foreground)
+	} else {
+		drawTriangle(ctx, cx-size, cy-size/2, cx+size, cy-size/2, cx, cy+size/2, state.Foreground)
+	}
+}
+
+// Measure returns the size of the combo box.
+func (c *ComboBoxComponent) Measure(cons Constraints) SizeHints {
+	return BoundsSizeHints(c.r)
+}
+
+// newInput creates the text input within the border and spacing of the theme, leaving room for the arrow.
+func (c *ComboBoxComponent) newInput(t *Theme) *TextInputComponent {
+	c.theme = t
+	r := image.Rect(c.r.Min.X+t.Spacing.M, c.r.Min.Y+t.BorderWidth, c.arrow().Min.X, c.r.Max.Y-t.BorderWidth)
+	return TextInput(r, &TextInputOptions{
+		Font:        c.opts.Font,
+		FontSize:    c.opts.FontSize,
+		TextColor:   c.opts.TextColor,
+		Placeholder: c.opts.Placeholder,
+		MaxLength:   c.opts.MaxLength,
+		OnChange:    c.changed,
+	})
+}
+
+// arrow returns the square at the right of the combo box which opens the list.
+func (c *ComboBoxComponent) arrow() image.Rectangle {
+	return image.Rect(c.r.Max.X-c.r.Dy(), c.r.Min.Y, c.r.Max.X, c.r.Max.Y)
+}
+
+// changed filters the list, opens it while there are matches and calls OnChange.
+func (c *ComboBoxComponent) changed(text string) {
+	c.filter()
+	c.open = len(c.matches) > 0
+	if c.opts.OnChange != nil {
+		c.opts.OnChange(text)
+	}
+}
+
+// filter finds the items which contain the text, ignoring case. An empty text matches all items.
+func (c *ComboBoxComponent) filter() {
+	text := strings.ToLower(c.input.Text())
+	c.matches = c.matches[:0]
+	for i := 0; i < len(c.items); i++ {
+		if strings.Contains(strings.ToLower(c.items[i]), text) {
+			c.matches = append(c.matches, i)
+		}
+	}
+	c.highlighted = -1
+	c.scroll = 0
+	if len(c.matches) == 0 {
+		c.open = false
+	}
+}
+
+// highlight highlights the match and scrolls it into view. The list is opened if it is closed.
+func (c *ComboBoxComponent) highlight(index int) {
+	if !c.open {
+		c.SetOpen(true)
+		return
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(c.matches) {
+		index = len(c.matches) - 1
+	}
+	c.highlighted = index
+	if index < c.scroll {
+		c.scroll = index
+	} else if index >= c.scroll+c.visible() {
+		c.scroll = index - c.visible() + 1
+	}
+}
+
+// clampScroll keeps the scroll offset within the matches.
+func (c *ComboBoxComponent) clampScroll() {
+	if max := len(c.matches) - c.visible(); c.scroll > max {
+		c.scroll = max
+	}
+	if c.scroll < 0 {
+		c.scroll = 0
+	}
+}
+
+// visible returns the number of matches shown in the list.
+func (c *ComboBoxComponent) visible() int {
+	if len(c.matches) < c.maxVisible {
+		return len(c.matches)
+	}
+	return c.maxVisible
+}
+
+// comboList is the list of a combo box shown in an overlay layer.
+type comboList struct {
+	c *ComboBoxComponent
+}
+
+// HandleMouseEvent highlights the match under the mouse, chooses the match which is clicked and scrolls with the wheel.
+// The event is consumed so the components below the list are not affected.
+func (l *comboList) HandleMouseEvent(evt *PointerEvent) bool {
+	c := l.c
+	index := c.scroll + evt.LocalY/c.itemHeight
+	switch evt.EventType {
+	case MouseMoveEvent, MouseEnterEvent:
+		if index < len(c.matches) {
+			c.highlighted = index
+		}
+	case MouseWheelEvent:
+		c.scroll -= wheelSteps(&c.wheel, evt.WheelY)
+		c.clampScroll()
+	case MouseReleaseEvent:
+		if evt.Button == ebiten.MouseButtonLeft && index < len(c.matches) {
+			c.Select(c.matches[index])
+		}
+	}
+
+	// keep the focus on the combo box
+	evt.PreventDefault()
+	return true
+}
+
+// Update is a no-op. The items are updated by the combo box.
+func (l *comboList) Update(ctx *UpdateContext) error {
+	return nil
+}
+
+// Measure returns the size of the visible matches.
+func (l *comboList) Measure(cons Constraints) SizeHints {
+	return FixedSizeHints(l.c.r.Dx(), l.c.visible()*l.c.itemHeight)
+}
+
+// Display renders the visible matches, the highlight and the scroll bar.
+func (l *comboList) Display(ctx *DisplayContext) {
+	c := l.c
+	rows := make([]*DynamicTextComponent, c.visible())
+	for i := 0; i < len(rows); i++ {
+		rows[i] = c.texts[c.matches[c.scroll+i]]
+	}
+	drawItemList(ctx, c.r.Dx(), c.itemHeight, rows, c.highlighted-c.scroll, c.scroll, len(c.matches))
+}
@@ -7,7 +7,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// ContainerOptions stores the options for a container.
+// ContainerOptions stores the options for a container. If Flex is set, the children are positioned by a flex layout
+// within the padding instead of at their own coordinates.
 type ContainerOptions struct {
 	FillColor color.Color
 	CenterX   bool
@@ -15,32 +16,90 @@ type ContainerOptions struct {
 	Margin    Quad
 	Border    Border
 	Padding   Quad
+	Flex      *FlexOptions
 }
 
-// Container creates a container component.
+// Container creates a container component. A container with a layout and an empty width or height is sized to its
+// content. Mouse events over the children are forwarded to them and their focusable components can take focus.
 func Container(r image.Rectangle, opts *ContainerOptions, children ...Component) Component {
-	x, y, w, h := r.Min.X, r.Min.Y, r.Dx(), r.Dy()
+	var layout layoutFunc
+	if opts.Flex != nil {
+		layout = func(bounds image.Rectangle, children []Component) []image.Rectangle {
+			items := make([]FlexItemOptions, len(children))
+			for i := 0; i < len(children); i++ {
+				items[i] = flexItemOptions(children[i])
+			}
+			return FlexLayout(bounds, opts.Flex, items)
+		}
+	}
+	return newContainer(r, opts, layout, children)
+}
+
+// newContainer creates a container which positions its children with the layout.
+func newContainer(r image.Rectangle, opts *ContainerOptions, layout layoutFunc, children []Component) *containerComponent {
+	c := &containerComponent{opts: opts, children: children, layout: layout, theme: DefaultTheme}
+
+	// size to the content when the rectangle is empty
+	if layout != nil && (r.Dx() == 0 || r.Dy() == 0) {
+		c.r = r
+		size := c.Measure(Constraints{MaxWidth: r.Dx(), MaxHeight: r.Dy()}).Preferred
+		if r.Dx() == 0 {
+			r.Max.X = r.Min.X + size.X
+		}
+		if r.Dy() == 0 {
+			r.Max.Y = r.Min.Y + size.Y
+		}
+	}
+	c.Arrange(r)
+	return c
+}
+
+// layoutFunc computes the rectangles of the children within the bounds.
+type layoutFunc func(bounds image.Rectangle, children []Component) []image.Rectangle
+
+// containerComponent renders its children clipped to the interior of a bordered rectangle.
+type containerComponent struct {
+	opts     *ContainerOptions
+	children []Component
+	layout   layoutFunc
+	theme    *Theme
+
+	r            image.Rectangle
+	rect         Component
+	internalRect image.Rectangle
+	bufferImage  *ebiten.Image
+	childRects   []image.Rectangle
+}
+
+// Arrange resizes the container and lays out the children.
+func (c *containerComponent) Arrange(r image.Rectangle) {
+	opts := c.opts
+	c.r = r
+	w, h := r.Dx(), r.Dy()
 	borderRect := Rect(
 		0, 0,
 		w-opts.Margin.Left-opts.Margin.Right-opts.Border.Left.Width-opts.Border.Right.Width,
 		h-opts.Margin.Top-opts.Margin.Bottom-opts.Border.Top.Width-opts.Border.Bottom.Width,
 	)
 
-	rect := Rectangle(borderRect, &RectangleOptions{
+	// layouts may arrange the container before it has a size
+	if borderRect.Dx() < 1 || borderRect.Dy() < 1 {
+		borderRect = Rect(0, 0, 1, 1)
+	}
+
+	c.rect = Rectangle(borderRect, &RectangleOptions{
 		FillColor: opts.FillColor,
 		CenterX:   opts.CenterX,
 		CenterY:   opts.CenterY,
-		Border:    opts.Border,
+		Border:    themedBorder(opts.Border, c.theme),
 	})
 	// fmt.Printf("Margin=(%s) Padding=(%s)\n", opts.Margin, opts.Padding)
 	// fmt.Printf("X=%d, Y=%d, W=%d, H=%d\n", x, y, w, h)
 
-	internalComponents := StackedComponent(children...)
-
 	internalLeft := opts.Border.Left.Width + opts.Padding.Left
-	internalTop := opts.Border.Top.Width + opts.Padding.Right
+	internalTop := opts.Border.Top.Width + opts.Padding.Top
 
-	internalRect := Rect(
+	c.internalRect = Rect(
 		internalLeft,
 		internalTop,
 		borderRect.Dx()-opts.Padding.Left-opts.Padding.Right,
@@ -49,40 +108,212 @@ func Container(r image.Rectangle, opts *ContainerOptions, children ...Component)
 
 	// fmt.Printf("Offset X=%d, Offset Y=%d\n", internalLeft, internalTop)
 
-	interiorWidth := internalRect.Dx()
-	interiorHeight := internalRect.Dy()
+	interiorWidth := c.internalRect.Dx()
+	interiorHeight := c.internalRect.Dy()
+	if interiorWidth < 1 {
+		interiorWidth = 1
+	}
+	if interiorHeight < 1 {
+		interiorHeight = 1
+	}
 
-	bufferImage := ebiten.NewImage(interiorWidth, interiorHeight)
+	c.bufferImage = ebiten.NewImage(interiorWidth, interiorHeight)
 	// fmt.Printf("Interior W=%d, Interior H=%d\n", interiorWidth, interiorHeight)
 
-	// interiorImage := DynamicImage(&ImageOptions{
-	// 	X:       float64(internalRect.Min.X),
-	// 	Y:       float64(internalRect.Min.Y),
-	// 	CenterX: opts.CenterX,
-	// 	CenterY: opts.CenterY,
-	// 	// X: float64(x + opts.Margin.Left), //+ opts.Border.Left.Width
-	// 	// Y: float64(y + opts.Margin.Top),  //+ opts.Border.Top.Width
-	// })
-
-	return ComponentFunc(func(ctx *UpdateContext) error {
-		return internalComponents.Update(ctx)
-	}, func(ctx *DisplayContext) {
-		ctx = ctx.Translate(float64(x), float64(y))
-
-		// margin context
-		marginContext := ctx.Translate(float64(opts.Margin.Left), float64(opts.Margin.Top))
-		rect.Display(marginContext)
-
-		bufferImage.Fill(color.Transparent)
-		bufferCtx := NewDisplayContext(ctx.Context(), bufferImage)
-
-		// render sub components onto buffered image
-		internalComponents.Display(bufferCtx)
-
-		// render buffered image
-		paddingCtx := marginContext.Translate(float64(internalRect.Min.X), float64(internalRect.Min.Y))
-		paddingCtx.DrawImage(bufferImage, &ebiten.DrawImageOptions{})
-	})
+	// lay out the children within the interior
+	c.childRects = nil
+	if c.layout != nil {
+		c.childRects = c.layout(image.Rect(0, 0, interiorWidth, interiorHeight), c.children)
+
+		for i := 0; i < len(c.children); i++ {
+			if a, ok := c.children[i].(Arranger); ok {
+				a.Arrange(c.childRects[i])
+			}
+		}
+	}
+}
+
+// Update updates the children. A container with themed border colors is redrawn when the theme changes.
+func (c *containerComponent) Update(ctx *UpdateContext) error {
+	if t := ThemeFromContext(ctx.Context()); t != c.theme && needsThemedBorder(c.opts.Border) {
+		c.theme = t
+		c.Arrange(c.r)
+	}
+	for i := 0; i < len(c.children); i++ {
+		if err := c.children[i].Update(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Display renders the border and the children.
+func (c *containerComponent) Display(ctx *DisplayContext) {
+	ctx = ctx.Translate(float64(c.r.Min.X), float64(c.r.Min.Y))
+
+	// margin context
+	marginContext := ctx.Translate(float64(c.opts.Margin.Left), float64(c.opts.Margin.Top))
+	c.rect.Display(marginContext)
+
+	c.bufferImage.Fill(color.Transparent)
+	bufferCtx := NewDisplayContext(ctx.Context(), c.bufferImage)
+
+	// render sub components onto buffered image
+	for i := 0; i < len(c.children); i++ {
+		childCtx := bufferCtx
+
+		// arrangers position themselves within their rectangle
+		if c.childRects != nil {
+			if _, ok := c.children[i].(Arranger); !ok {
+				offset := arrangedOffset(c.children[i], c.childRects[i])
+				childCtx = bufferCtx.Translate(float64(offset.X), float64(offset.Y))
+			}
+		}
+		c.children[i].Display(childCtx)
+	}
+
+	// render buffered image
+	paddingCtx := marginContext.Translate(float64(c.internalRect.Min.X), float64(c.internalRect.Min.Y))
+	paddingCtx.DrawImage(c.bufferImage, &ebiten.DrawImageOptions{})
+}
+
+// Contains returns true if the point is within the interior and over a child which can be hit, so the container only
+// takes mouse events meant for its children.
+func (c *containerComponent) Contains(x, y int) bool {
+	x, y, ok := c.interiorPoint(x, y)
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(c.children); i++ {
+		if h, ok := c.children[i].(Hittable); ok && h.Contains(c.childPoint(i, x, y)) {
+			return true
+		}
+	}
+	return false
+}
+
+// OnMouseEvent forwards the event to the children which handle mouse buttons.
+func (c *containerComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	x, y, _ = c.interiorPoint(x, y)
+	for i := 0; i < len(c.children); i++ {
+		if h, ok := c.children[i].(MouseButtonHandler); ok {
+			cx, cy := c.childPoint(i, x, y)
+			h.OnMouseEvent(cx, cy, evt)
+		}
+	}
+}
+
+// OnMouseMove forwards the move to the children which handle mouse moves.
+func (c *containerComponent) OnMouseMove(x, y int) {
+	x, y, _ = c.interiorPoint(x, y)
+	for i := 0; i < len(c.children); i++ {
+		if h, ok := c.children[i].(MouseMoveHandler); ok {
+			h.OnMouseMove(c.childPoint(i, x, y))
+		}
+	}
+}
+
+// focusableAt returns the topmost focusable child containing the point.
+func (c *containerComponent) focusableAt(x, y int) Focusable {
+	x, y, ok := c.interiorPoint(x, y)
+	if !ok {
+		return nil
+	}
+	for i := len(c.children) - 1; i >= 0; i-- {
+		cx, cy := c.childPoint(i, x, y)
+		if f := focusableAt(c.children[i], cx, cy); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// components returns the children.
+func (c *containerComponent) components() []Component {
+	return c.children
+}
+
+// interiorPoint converts a point from the coordinate space the container is drawn in to the interior, where the
+// children are drawn. The point is outside the interior if the children are clipped there.
+func (c *containerComponent) interiorPoint(x, y int) (int, int, bool) {
+	x -= c.r.Min.X + c.opts.Margin.Left + c.internalRect.Min.X
+	y -= c.r.Min.Y + c.opts.Margin.Top + c.internalRect.Min.Y
+	w, h := c.bufferImage.Size()
+	return x, y, image.Pt(x, y).In(image.Rect(0, 0, w, h))
+}
+
+// childPoint converts a point in the interior to the coordinate space of the child.
+func (c *containerComponent) childPoint(i, x, y int) (int, int) {
+	if c.childRects != nil {
+		if _, ok := c.children[i].(Arranger); !ok {
+			offset := arrangedOffset(c.children[i], c.childRects[i])
+			return x - offset.X, y - offset.Y
+		}
+	}
+	return x, y
+}
+
+// Measure returns the size of the container. Containers with a layout prefer the size of their content.
+func (c *containerComponent) Measure(cons Constraints) SizeHints {
+	size := c.r.Size()
+	if c.layout == nil {
+		return FixedSizeHints(size.X, size.Y)
+	}
+
+	opts := c.opts
+	extraW := opts.Margin.Left + opts.Margin.Right + opts.Border.Left.Width + opts.Border.Right.Width + opts.Padding.Left + opts.Padding.Right
+	extraH := opts.Margin.Top + opts.Margin.Bottom + opts.Border.Top.Width + opts.Border.Bottom.Width + opts.Padding.Top + opts.Padding.Bottom
+
+	// lay out the content within the constraints
+	bounds := image.Rect(0, 0, cons.MaxWidth-extraW, cons.MaxHeight-extraH)
+	if cons.MaxWidth == 0 {
+		bounds.Max.X = 0
+	}
+	if cons.MaxHeight == 0 {
+		bounds.Max.Y = 0
+	}
+	content := c.contentSize(bounds)
+
+	preferred := image.Pt(content.X+extraW, content.Y+extraH)
+	return SizeHints{Min: preferred, Preferred: preferred}
+}
+
+// contentSize returns the size of the children when laid out within the bounds.
+func (c *containerComponent) contentSize(bounds image.Rectangle) image.Point {
+	// flex content is measured as a single line unless it wraps within a bounded width
+	if flex := c.opts.Flex; flex != nil && !(flex.Wrap && bounds.Dx() > 0) {
+		var size image.Point
+		for i := 0; i < len(c.children); i++ {
+			item := flexItemOptions(c.children[i])
+			w := item.Width + item.Margin.Left + item.Margin.Right
+			h := item.Height + item.Margin.Top + item.Margin.Bottom
+			if flex.Direction == FlexRow {
+				size.X += w
+				if h > size.Y {
+					size.Y = h
+				}
+			} else {
+				size.Y += h
+				if w > size.X {
+					size.X = w
+				}
+			}
+		}
+		if n := len(c.children); n > 1 {
+			if flex.Direction == FlexRow {
+				size.X += flex.Gap * (n - 1)
+			} else {
+				size.Y += flex.Gap * (n - 1)
+			}
+		}
+		return size
+	}
+
+	var union image.Rectangle
+	for _, r := range c.layout(bounds, c.children) {
+		union = union.Union(r)
+	}
+	return union.Max
 }
 
 // BoxCorners draws corners on a box.
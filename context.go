@@ -4,6 +4,7 @@ import (
 	"context"
 	"image"
 	"image/color"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -36,7 +37,7 @@ type contextKey string
 func NewDisplayContext(ctx context.Context, i *ebiten.Image) *DisplayContext {
 	src := ebiten.NewImage(3, 3)
 	src.Fill(color.White)
-	return &DisplayContext{ctx, 0, 0, i, src}
+	return &DisplayContext{ctx, 0, 0, i, src, EbitenInput}
 }
 
 // DisplayContext manages drawing the component in the parent component.
@@ -46,6 +47,7 @@ type DisplayContext struct {
 	dx, dy     float64
 	parent     *ebiten.Image
 	emptyImage *ebiten.Image
+	input      InputSource
 }
 
 // Image returns the image for the parent context.
@@ -60,36 +62,52 @@ func (c *DisplayContext) Context() context.Context {
 
 // Translate creates a new context after translated.
 func (c *DisplayContext) Translate(x, y float64) *DisplayContext {
-	return &DisplayContext{c.context, c.dx + x, c.dy + y, c.parent, c.emptyImage}
+	return &DisplayContext{c.context, c.dx + x, c.dy + y, c.parent, c.emptyImage, c.input}
 }
 
 // DrawImage draws the image on the parent image.
 func (c *DisplayContext) DrawImage(i *ebiten.Image, op *ebiten.DrawImageOptions) {
-	if c.dx > 0 || c.dy > 0 {
+	if c.dx != 0 || c.dy != 0 {
 		op.GeoM.Translate(c.dx, c.dy)
 	}
 	c.parent.DrawImage(i, op)
 }
 
-// DrawTriangles draws triangles on the parent image.
+// DrawTriangles draws triangles on the parent image. The vertices are translated by the context like DrawImage; the
+// slice is not modified.
 func (c *DisplayContext) DrawTriangles(vs []ebiten.Vertex, is []uint16, op *ebiten.DrawTrianglesOptions) {
 	src := c.emptyImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+
+	// translate a copy of the vertices so the caller's slice is unchanged
+	if c.dx != 0 || c.dy != 0 {
+		translated := make([]ebiten.Vertex, len(vs))
+		for i := 0; i < len(vs); i++ {
+			translated[i] = vs[i]
+			translated[i].DstX += float32(c.dx)
+			translated[i].DstY += float32(c.dy)
+		}
+		vs = translated
+	}
 	c.parent.DrawTriangles(vs, is, src, op)
 }
 
 // CursorPosition returns the mouse position.
 func (c *DisplayContext) CursorPosition() (int, int) {
-	return ebiten.CursorPosition()
+	return c.input.CursorPosition()
 }
 
 // NewUpdateContext creates a new UpdateContext with the provided context.Context.
 func NewUpdateContext(ctx context.Context) *UpdateContext {
-	return &UpdateContext{ctx}
+	return &UpdateContext{context: ctx, input: EbitenInput}
 }
 
 // UpdateContext provides a simple context and a way to pass information to child components during update.
 type UpdateContext struct {
-	context context.Context
+	context  context.Context
+	input    InputSource
+	clock    *Clock
+	node     *Node
+	overlays *OverlayManager
 }
 
 // Context returns the context.Context.
@@ -99,5 +117,60 @@ func (u *UpdateContext) Context() context.Context {
 
 // CursorPosition returns the mouse position.
 func (u *UpdateContext) CursorPosition() (int, int) {
-	return ebiten.CursorPosition()
+	return u.input.CursorPosition()
+}
+
+// Clock returns the game clock or nil if the context was not created by a display.
+func (u *UpdateContext) Clock() *Clock {
+	return u.clock
+}
+
+// Frame returns the number of ticks since the display started.
+func (u *UpdateContext) Frame() int64 {
+	if u.clock == nil {
+		return 0
+	}
+	return u.clock.Frame()
+}
+
+// Elapsed returns the game time since the display started.
+func (u *UpdateContext) Elapsed() time.Duration {
+	if u.clock == nil {
+		return 0
+	}
+	return u.clock.Elapsed()
+}
+
+// Delta returns the game time since the last tick.
+func (u *UpdateContext) Delta() time.Duration {
+	if u.clock == nil {
+		return 0
+	}
+	return u.clock.Delta()
+}
+
+// Input returns the source of the mouse and keyboard input.
+func (u *UpdateContext) Input() InputSource {
+	return u.input
+}
+
+// Node returns the scene graph node being updated, whose component is or contains the component being updated, or nil
+// if the component is not in a scene graph.
+func (u *UpdateContext) Node() *Node {
+	return u.node
+}
+
+// Overlay returns the root node of the overlay layers of the display, which is drawn above and hit before all other
+// components, or nil if the context was not created by a display.
+func (u *UpdateContext) Overlay() *Node {
+	if u.overlays == nil {
+		return nil
+	}
+	return u.overlays.Root()
+}
+
+// Overlays returns the overlay layers of the display, which are drawn above and hit before all other components, or nil
+// if the context was not created by a display.
+func (u *UpdateContext) Overlays() *OverlayManager {
+	return u.overlays
 }
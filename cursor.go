@@ -20,7 +20,7 @@ func TriangleCursor(c color.Color) Component {
 	}
 
 	return SimpleComponent(func(ctx *DisplayContext) {
-		mouseX, mouseY := ebiten.CursorPosition()
+		mouseX, mouseY := ctx.CursorPosition()
 
 		if mouseX > 1e4 || mouseX < -1e4 {
 			return
@@ -40,7 +40,7 @@ func ImageCursor(i *ebiten.Image, center bool) Component {
 
 	op := &ebiten.DrawImageOptions{}
 	return SimpleComponent(func(ctx *DisplayContext) {
-		mouseX, mouseY := ebiten.CursorPosition()
+		mouseX, mouseY := ctx.CursorPosition()
 		op.GeoM.Translate(float64(mouseX), float64(mouseY))
 
 		if center {
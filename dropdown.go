@@ -0,0 +1,540 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// These are the defaults for the dropdown list.
+const (
+	DefaultDropdownMaxVisible = 8
+	typeAheadTimeout          = time.Second
+)
+
+// DropdownOptions contains the options for a dropdown. Unset fonts and colors follow the theme. The list shows up to
+// MaxVisible items, which defaults to 8, of ItemHeight pixels, which defaults to the height of the dropdown and is at
+// least 1. A negative Selected starts with no item selected and shows the placeholder. The list is shown in the overlay
+// Layer, which defaults to the menu layer so dropdowns within modal dialogs open their list above the dialog. Nil
+// options are empty options.
+type DropdownOptions struct {
+	Font        string
+	FontSize    float64
+	TextColor   color.Color
+	Placeholder string
+	Selected    int
+	MaxVisible  int
+	ItemHeight  int
+	Disabled    bool
+	Layer       string
+
+	// OnSelect is called whenever the user selects an item.
+	OnSelect func(index int, item string)
+}
+
+// Dropdown creates a themed dropdown which shows the selected item in the rectangle. Clicking it opens a list of the
+// items in an overlay layer of the display, above all other components. The list highlights the item under the mouse,
+// scrolls with the wheel and closes when an item is chosen or the mouse is pressed outside of it.
+//
+// While focused, the arrow keys, Page Up, Page Down, Home and End move the highlight, Enter or Space opens the list or
+// chooses the highlighted item and typing jumps to the next item starting with the typed text. The dropdown must be
+// added to a display so the list can be shown in its overlay layers.
+func Dropdown(r image.Rectangle, items []string, opts *DropdownOptions) *DropdownComponent {
+	if opts == nil {
+		opts = &DropdownOptions{}
+	}
+	maxVisible := opts.MaxVisible
+	if maxVisible <= 0 {
+		maxVisible = DefaultDropdownMaxVisible
+	}
+	itemHeight := opts.ItemHeight
+	if itemHeight <= 0 {
+		itemHeight = r.Dy()
+	}
+	if itemHeight < 1 {
+		itemHeight = 1
+	}
+
+	textOpts := &TextOptions{Font: opts.Font, FontSize: opts.FontSize, TextColor: opts.TextColor}
+	d := &DropdownComponent{
+		r:           r,
+		opts:        opts,
+		items:       items,
+		selected:    -1,
+		highlighted: -1,
+		disabled:    opts.Disabled,
+		maxVisible:  maxVisible,
+		itemHeight:  itemHeight,
+		value:       DynamicText(textOpts),
+		texts:       make([]*DynamicTextComponent, len(items)),
+	}
+	if opts.Selected >= 0 && opts.Selected < len(items) {
+		d.selected = opts.Selected
+	}
+	for i := 0; i < len(items); i++ {
+		d.texts[i] = DynamicText(textOpts).SetText(items[i])
+	}
+	d.list = &dropdownList{d}
+	return d
+}
+
+// DropdownComponent shows the selected item and a list of items to choose from.
+type DropdownComponent struct {
+	r     image.Rectangle
+	opts  *DropdownOptions
+	items []string
+
+	selected    int
+	highlighted int
+	scroll      int
+	wheel       float64
+	maxVisible  int
+	itemHeight  int
+
+	open     bool
+	disabled bool
+	focused  bool
+	hover    bool
+	pressed  bool
+
+	value *DynamicTextComponent
+	texts []*DynamicTextComponent
+	list  *dropdownList
+	popup *Popup
+
+	now       time.Duration
+	typedAt   time.Duration
+	typeAhead string
+}
+
+// Selected returns the index of the selected item or -1 if no item is selected.
+func (d *DropdownComponent) Selected() int {
+	return d.selected
+}
+
+// SelectedItem returns the selected item or an empty string if no item is selected.
+func (d *DropdownComponent) SelectedItem() string {
+	if d.selected < 0 {
+		return ""
+	}
+	return d.items[d.selected]
+}
+
+// SetSelected selects the item without calling OnSelect. A negative index clears the selection.
+func (d *DropdownComponent) SetSelected(index int) *DropdownComponent {
+	if index < len(d.items) {
+		if index < 0 {
+			index = -1
+		}
+		d.selected = index
+	}
+	return d
+}
+
+// Select selects the item, closes the list and calls OnSelect.
+func (d *DropdownComponent) Select(index int) {
+	if index < 0 || index >= len(d.items) {
+		return
+	}
+	d.selected = index
+	d.open = false
+	if d.opts.OnSelect != nil {
+		d.opts.OnSelect(index, d.items[index])
+	}
+}
+
+// Open returns true while the list is shown.
+func (d *DropdownComponent) Open() bool {
+	return d.open
+}
+
+// SetOpen shows or hides the list. The list is shown or closed during the next update.
+func (d *DropdownComponent) SetOpen(open bool) {
+	if open && (d.disabled || len(d.items) == 0) {
+		return
+	}
+	if open && !d.open {
+		d.highlighted = d.selected
+		d.scrollTo(d.highlighted)
+	}
+	d.open = open
+}
+
+// Disabled returns true if the dropdown ignores input.
+func (d *DropdownComponent) Disabled() bool {
+	return d.disabled
+}
+
+// SetDisabled enables or disables the dropdown. Disabling it closes the list.
+func (d *DropdownComponent) SetDisabled(disabled bool) {
+	d.disabled = disabled
+	if disabled {
+		d.open = false
+		d.pressed = false
+	}
+}
+
+// Focused returns true if the dropdown has keyboard focus.
+func (d *DropdownComponent) Focused() bool {
+	return d.focused
+}
+
+// OnFocus draws the focus border.
+func (d *DropdownComponent) OnFocus() {
+	d.focused = true
+}
+
+// OnBlur closes the list.
+func (d *DropdownComponent) OnBlur() {
+	d.focused = false
+	d.open = false
+}
+
+// Contains returns true if the point is within the dropdown.
+func (d *DropdownComponent) Contains(x, y int) bool {
+	return image.Pt(x, y).In(d.r)
+}
+
+// OnMouseEvent opens the list when the dropdown is clicked. Clicks while the list is open are handled by the overlay.
+func (d *DropdownComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if d.disabled || evt.Button != ebiten.MouseButtonLeft {
+		return
+	}
+	switch evt.EventType {
+	case MousePressEvent:
+		d.pressed = d.Contains(x, y)
+	case MouseReleaseEvent:
+		if d.pressed && d.Contains(x, y) {
+			d.SetOpen(true)
+		}
+		d.pressed = false
+	}
+}
+
+// OnMouseMove highlights the dropdown while the mouse is over it.
+func (d *DropdownComponent) OnMouseMove(x, y int) {
+	d.hover = d.Contains(x, y)
+}
+
+// OnKeyEvent moves the highlight and opens or chooses from the list.
+func (d *DropdownComponent) OnKeyEvent(evt KeyEvent) {
+	if d.disabled || evt.EventType == KeyReleaseEvent || len(d.items) == 0 {
+		return
+	}
+	switch evt.Key {
+	case ebiten.KeyEnter, ebiten.KeyKPEnter, ebiten.KeySpace:
+		if evt.EventType != KeyPressEvent {
+			return
+		}
+		if d.open && d.highlighted >= 0 {
+			d.Select(d.highlighted)
+		} else {
+			d.SetOpen(!d.open)
+		}
+	case ebiten.KeyDown:
+		if !d.open {
+			d.SetOpen(true)
+			return
+		}
+		d.highlight(d.highlighted + 1)
+	case ebiten.KeyUp:
+		d.highlight(d.highlighted - 1)
+	case ebiten.KeyPageDown:
+		d.highlight(d.highlighted + d.maxVisible)
+	case ebiten.KeyPageUp:
+		d.highlight(d.highlighted - d.maxVisible)
+	case ebiten.KeyHome:
+		d.highlight(0)
+	case ebiten.KeyEnd:
+		d.highlight(len(d.items) - 1)
+	}
+}
+
+// OnTextInput jumps to the next item which starts with the typed text. Text typed within a second is added to the
+// search. A closed dropdown selects the item.
+func (d *DropdownComponent) OnTextInput(chars []rune) {
+	if d.disabled {
+		return
+	}
+	if d.now-d.typedAt > typeAheadTimeout {
+		d.typeAhead = ""
+	}
+	d.typedAt = d.now
+	typed := strings.ToLower(string(chars))
+	if d.typeAhead == "" && strings.TrimSpace(typed) == "" {
+		return
+	}
+	d.typeAhead += typed
+
+	// a new search starts after the current item so repeating a letter cycles through the matches
+	start := d.selected
+	if d.open {
+		start = d.highlighted
+	}
+	if len([]rune(d.typeAhead)) == 1 {
+		start++
+	}
+	if start < 0 {
+		start = 0
+	}
+	for i := 0; i < len(d.items); i++ {
+		index := (start + i) % len(d.items)
+		if strings.HasPrefix(strings.ToLower(d.items[index]), d.typeAhead) {
+			if d.open {
+				d.highlight(index)
+			} else if index != d.selected {
+				d.Select(index)
+			}
+			return
+		}
+	}
+}
+
+// Update updates the text and shows or hides the list in the overlay.
+func (d *DropdownComponent) Update(ctx *UpdateContext) error {
+	d.now = ctx.Elapsed()
+	theme := ThemeFromContext(ctx.Context())
+
+	// the value shows the placeholder in the muted color without a selection
+	switch {
+	case d.disabled:
+		d.value.SetTextColor(theme.Widget.Disabled.Foreground)
+	case d.selected < 0:
+		d.value.SetTextColor(theme.Palette.TextMuted)
+	default:
+		d.value.SetTextColor(d.opts.TextColor)
+	}
+	if d.selected < 0 {
+		d.value.SetText(d.opts.Placeholder)
+	} else {
+		d.value.SetText(d.items[d.selected])
+	}
+	if err := d.value.Update(ctx); err != nil {
+		return err
+	}
+
+	// the list is placed below the dropdown in screen coordinates, or above it near the bottom of the screen
+	anchor := d.r
+	if n := ctx.Node(); n != nil {
+		x, y := n.ScreenPosition()
+		anchor = anchor.Add(image.Pt(int(x), int(y)))
+	}
+	if d.open && d.popup == nil {
+		if ctx.Overlays() == nil {
+			d.open = false
+			return nil
+		}
+		d.popup = ctx.Overlays().Show(d.list, &PopupOptions{
+			Layer:   listLayer(d.opts.Layer),
+			Anchor:  anchor,
+			Dismiss: true,
+			OnClose: func() {
+				d.open = false
+				d.popup = nil
+			},
+		})
+	} else if d.open {
+		d.popup.SetAnchor(anchor)
+	} else if d.popup != nil {
+		d.popup.Close()
+	}
+
+	if d.open {
+		for i := d.scroll; i < d.scroll+d.visible(); i++ {
+			c := d.opts.TextColor
+			if i == d.selected {
+				c = theme.Palette.Primary
+			}
+			d.texts[i].SetTextColor(c)
+			if err := d.texts[i].Update(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Display renders the selected item and the arrow.
+func (d *DropdownComponent) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	state := theme.Widget.Normal
+	switch {
+	case d.disabled:
+		state = theme.Widget.Disabled
+	case d.focused || d.open:
+		state = theme.Widget.Focused
+	case d.hover:
+		state = theme.Widget.Hover
+	}
+	drawBox(ctx, d.r, state.Background, state.Border, theme.BorderWidth)
+
+	pad := theme.Spacing.M
+	if d.value.Text() != "" {
+		drawTextAt(ctx, d.value, d.r.Min.X+pad, textBaseline(d.value, d.r))
+	}
+
+	// arrow
+	size := float32(d.r.Dy()) / 4
+	cx, cy := float32(d.r.Max.X-pad)-size, float32(d.r.Min.Y)+float32(d.r.Dy())/2
+	if d.open {
+		drawTriangle(ctx, cx-size, cy+size/2, cx+size, cy+size/2, cx, cy-size/2, state.Foreground)
+	} else {
+		drawTriangle(ctx, cx-size, cy-size/2, cx+size, cy-size/2, cx, cy+size/2, state.Foreground)
+	}
+}
+
+// Measure returns the size of the dropdown.
+func (d *DropdownComponent) Measure(c Constraints) SizeHints {
+	return BoundsSizeHints(d.r)
+}
+
+// highlight highlights the item and scrolls it into view. The list is opened if it is closed.
+func (d *DropdownComponent) highlight(index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(d.items) {
+		index = len(d.items) - 1
+	}
+	d.SetOpen(true)
+	d.highlighted = index
+	d.scrollTo(index)
+}
+
+// scrollTo scrolls the list so the item is visible.
+func (d *DropdownComponent) scrollTo(index int) {
+	if index < d.scroll {
+		d.scroll = index
+	} else if index >= d.scroll+d.visible() {
+		d.scroll = index - d.visible() + 1
+	}
+	d.clampScroll()
+}
+
+// clampScroll keeps the scroll offset within the items.
+func (d *DropdownComponent) clampScroll() {
+	if max := len(d.items) - d.visible(); d.scroll > max {
+		d.scroll = max
+	}
+	if d.scroll < 0 {
+		d.scroll = 0
+	}
+}
+
+// visible returns the number of items shown in the list.
+func (d *DropdownComponent) visible() int {
+	if len(d.items) < d.maxVisible {
+		return len(d.items)
+	}
+	return d.maxVisible
+}
+
+// textBaseline returns the baseline which centers the text vertically in the rectangle.
+func textBaseline(t *DynamicTextComponent, r image.Rectangle) int {
+	m := t.FontFace().Metrics()
+	return r.Min.Y + (r.Dy()+m.Ascent.Ceil()-m.Descent.Ceil())/2
+}
+
+// dropdownList is the list of a dropdown shown in an overlay layer.
+type dropdownList struct {
+	d *DropdownComponent
+}
+
+// HandleMouseEvent highlights the item under the mouse, chooses the item which is clicked and scrolls with the wheel.
+// The event is consumed so the components below the list are not affected.
+func (l *dropdownList) HandleMouseEvent(evt *PointerEvent) bool {
+	d := l.d
+	index := d.scroll + evt.LocalY/d.itemHeight
+	switch evt.EventType {
+	case MouseMoveEvent, MouseEnterEvent:
+		if index < len(d.items) {
+			d.highlighted = index
+		}
+	case MouseWheelEvent:
+		d.scroll -= wheelSteps(&d.wheel, evt.WheelY)
+		d.clampScroll()
+	case MouseReleaseEvent:
+		if evt.Button == ebiten.MouseButtonLeft && index < len(d.items) {
+			d.Select(index)
+		}
+	}
+
+	// keep the focus on the dropdown
+	evt.PreventDefault()
+	return true
+}
+
+// Update is a no-op. The items are updated by the dropdown.
+func (l *dropdownList) Update(ctx *UpdateContext) error {
+	return nil
+}
+
+// Measure returns the size of the visible items.
+func (l *dropdownList) Measure(c Constraints) SizeHints {
+	return FixedSizeHints(l.d.r.Dx(), l.d.visible()*l.d.itemHeight)
+}
+
+// Display renders the visible items, the highlight and the scroll bar.
+func (l *dropdownList) Display(ctx *DisplayContext) {
+	d := l.d
+	drawItemList(ctx, d.r.Dx(), d.itemHeight, d.texts[d.scroll:d.scroll+d.visible()], d.highlighted-d.scroll, d.scroll, len(d.items))
+}
+
+// drawItemList renders the rows of a list, the highlighted row and a scroll bar if the rows are scrolled from the
+// total. The highlighted row is relative to the first row.
+func drawItemList(ctx *DisplayContext, w, itemHeight int, rows []*DynamicTextComponent, highlighted, scroll, total int) {
+	theme := ThemeFromContext(ctx.Context())
+	h := len(rows) * itemHeight
+	drawBox(ctx, Rect(0, 0, w, h), theme.Palette.Surface, theme.Palette.Border, theme.BorderWidth)
+
+	pad := theme.Spacing.M
+	op := &ebiten.DrawTrianglesOptions{}
+	for i := 0; i < len(rows); i++ {
+		row := Rect(theme.BorderWidth, i*itemHeight, w-2*theme.BorderWidth, itemHeight)
+		if i == highlighted {
+			vs, is := RectVertices(row.Min.X, row.Min.Y, row.Max.X, row.Max.Y, theme.Palette.Selection)
+			ctx.DrawTriangles(vs, is, op)
+		}
+		drawTextAt(ctx, rows[i], row.Min.X+pad, textBaseline(rows[i], row))
+	}
+
+	// scroll bar
+	if total > len(rows) {
+		thumb := h * len(rows) / total
+		top := h * scroll / total
+		vs, is := RectVertices(w-theme.BorderWidth-4, top, w-theme.BorderWidth, top+thumb, theme.Palette.TextMuted)
+		ctx.DrawTriangles(vs, is, op)
+	}
+}
+
+// listLayer returns the overlay layer of a list, which defaults to the menu layer above modal dialogs.
+func listLayer(layer string) string {
+	if layer == "" {
+		return MenuLayer
+	}
+	return layer
+}
+
+// wheelSteps adds the wheel offset to the accumulated offset and returns the whole steps. The fraction is kept for the
+// next events so slow scrolling with a touchpad still scrolls.
+func wheelSteps(acc *float64, offset float64) int {
+	*acc += offset
+	steps := int(*acc)
+	*acc -= float64(steps)
+	return steps
+}
+
+// drawTriangle fills the triangle.
+func drawTriangle(ctx *DisplayContext, x0, y0, x1, y1, x2, y2 float32, c color.Color) {
+	clr := RGBA(c)
+	r, g, b, a := float32(clr.R)/0xff, float32(clr.G)/0xff, float32(clr.B)/0xff, float32(clr.A)/0xff
+	vs := []ebiten.Vertex{
+		{DstX: x0, DstY: y0, SrcX: 1, SrcY: 1, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+		{DstX: x1, DstY: y1, SrcX: 1, SrcY: 1, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+		{DstX: x2, DstY: y2, SrcX: 1, SrcY: 1, ColorR: r, ColorG: g, ColorB: b, ColorA: a},
+	}
+	ctx.DrawTriangles(vs, []uint16{0, 1, 2}, &ebiten.DrawTrianglesOptions{})
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+)
+
+// EventPhase enumerates the phases of event propagation through the scene graph.
+type EventPhase int
+
+// String returns the string representation of the phase.
+func (p EventPhase) String() string {
+	switch p {
+	case CapturePhase:
+		return "Capture"
+	case TargetPhase:
+		return "Target"
+	case BubblePhase:
+		return "Bubble"
+	}
+	return "None"
+}
+
+const (
+
+	// NoPhase is the phase of an event which is not being dispatched.
+	NoPhase EventPhase = iota
+
+	// CapturePhase occurs as the event travels from the root down to the parent of the target.
+	CapturePhase
+
+	// TargetPhase occurs when the event reaches the target.
+	TargetPhase
+
+	// BubblePhase occurs as the event travels from the parent of the target back up to the root.
+	BubblePhase
+)
+
+// PointerEvent is a mouse event propagated through the scene graph. X and Y are screen coordinates while LocalX and
+// LocalY are relative to the current target.
+type PointerEvent struct {
+	MouseEvent
+	X, Y           int
+	LocalX, LocalY int
+
+	Phase         EventPhase
+	Target        *Node
+	CurrentTarget *Node
+
+	stopped   bool
+	prevented bool
+}
+
+// StopPropagation stops the event from reaching any further nodes. The remaining listeners of the current node are
+// still called.
+func (e *PointerEvent) StopPropagation() {
+	e.stopped = true
+}
+
+// PropagationStopped returns true if StopPropagation has been called.
+func (e *PointerEvent) PropagationStopped() bool {
+	return e.stopped
+}
+
+// PreventDefault cancels the default action of the event, such as focusing the component under the mouse.
+func (e *PointerEvent) PreventDefault() {
+	e.prevented = true
+}
+
+// DefaultPrevented returns true if PreventDefault has been called.
+func (e *PointerEvent) DefaultPrevented() bool {
+	return e.prevented
+}
+
+func (e *PointerEvent) String() string {
+	return fmt.Sprintf("PointerEvent: Button=%d Event=%s Phase=%s X=%d Y=%d", e.Button, e.EventType.String(), e.Phase.String(), e.X, e.Y)
+}
+
+// MouseEventListener handles mouse events in the target and bubble phases. Returning true consumes the event and stops
+// its propagation.
+type MouseEventListener interface {
+	HandleMouseEvent(evt *PointerEvent) bool
+}
+
+// MouseCaptureListener handles mouse events in the capture phase before they reach the target. Returning true
+// consumes the event and stops its propagation.
+type MouseCaptureListener interface {
+	CaptureMouseEvent(evt *PointerEvent) bool
+}
+
+// MouseListenerFunc creates a MouseEventListener from a function.
+func MouseListenerFunc(h func(evt *PointerEvent) bool) MouseEventListener {
+	return &simpleMouseListener{h}
+}
+
+// MouseCaptureListenerFunc creates a MouseCaptureListener from a function.
+func MouseCaptureListenerFunc(h func(evt *PointerEvent) bool) MouseCaptureListener {
+	return &simpleMouseListener{h}
+}
+
+type simpleMouseListener struct {
+	handler func(evt *PointerEvent) bool
+}
+
+func (s *simpleMouseListener) HandleMouseEvent(evt *PointerEvent) bool {
+	return s.handler(evt)
+}
+
+func (s *simpleMouseListener) CaptureMouseEvent(evt *PointerEvent) bool {
+	return s.handler(evt)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+var countries = []string{
+	"Argentina", "Australia", "Austria", "Belgium", "Brazil", "Canada", "Chile", "Denmark", "Egypt", "Finland",
+	"France", "Germany", "Greece", "India", "Ireland", "Italy", "Japan", "Kenya", "Mexico", "Netherlands",
+	"New Zealand", "Norway", "Peru", "Poland", "Portugal", "Spain", "Sweden", "Switzerland", "Turkey", "Vietnam",
+}
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Dropdown",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+
+	status := ui.DynamicText(&ui.TextOptions{}).SetText("Nothing selected")
+	status.SetPosition(32, 320)
+
+	country := ui.Dropdown(ui.Rect(32, 32, 200, 32), countries, &ui.DropdownOptions{
+		Placeholder: "Country",
+		Selected:    -1,
+		OnSelect: func(index int, item string) {
+			status.SetText(fmt.Sprintf("Selected: %s", item))
+		},
+	})
+	theme := ui.Dropdown(ui.Rect(264, 32, 200, 32), []string{"Light", "Dark"}, &ui.DropdownOptions{
+		OnSelect: func(index int, item string) {
+			if index == 0 {
+				display.SetTheme(ui.LightTheme())
+			} else {
+				display.SetTheme(ui.DarkTheme())
+			}
+		},
+	})
+
+	// typing filters the countries
+	search := ui.ComboBox(ui.Rect(264, 96, 200, 32), countries, &ui.ComboBoxOptions{
+		Placeholder: "Search countries",
+		OnSelect: func(index int, item string) {
+			status.SetText(fmt.Sprintf("Found: %s", item))
+		},
+	})
+
+	// the checkbox is covered by the open list
+	check := ui.Checkbox(32, 96, &ui.CheckboxOptions{Label: "Below the list"})
+
+	display.Add(check, country, theme, search, status)
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
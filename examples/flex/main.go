@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	screenWidth, screenHeight := 512, 512
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:           "Flex",
+		Width:           screenWidth,
+		Height:          screenHeight,
+		BackgroundColor: color.White,
+	})
+
+	// channel tiles wrap onto new rows
+	channels := make([]ui.Component, 0, 12)
+	for i := 0; i < 12; i++ {
+		channels = append(channels, ui.FlexItem(ui.Container(ui.Rect(0, 0, 128, 128), &ui.ContainerOptions{
+			Border: ui.StrokeBorder(color.Black, 4),
+			Flex: &ui.FlexOptions{
+				Justify:    ui.JustifyCenter,
+				AlignItems: ui.AlignCenter,
+			},
+		},
+			ui.Text(fmt.Sprintf("CH%d", i), 0, 0, &ui.TextOptions{
+				Font:      "letter-goth-std-med.otf",
+				FontSize:  32,
+				TextColor: color.Black,
+			}),
+		), &ui.FlexItemOptions{Width: 128, Height: 128}))
+	}
+
+	display.Add(ui.Container(ui.Rect(0, 0, 1024, 1024), &ui.ContainerOptions{
+		Margin:  ui.UniformQuad(32),
+		Padding: ui.UniformQuad(32),
+		Border:  ui.StrokeBorder(color.Black, 8),
+		Flex: &ui.FlexOptions{
+			Direction: ui.FlexRow,
+			Justify:   ui.JustifySpaceBetween,
+			Gap:       32,
+			Wrap:      true,
+		},
+	}, channels...))
+
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
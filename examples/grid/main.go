@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"image/color"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	screenWidth, screenHeight := 512, 512
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:           "Grid",
+		Width:           screenWidth,
+		Height:          screenHeight,
+		BackgroundColor: color.White,
+	})
+
+	label := func(msg string) ui.Component {
+		return ui.Text(msg, 0, 0, &ui.TextOptions{
+			Font:      "letter-goth-std-med.otf",
+			FontSize:  32,
+			TextColor: color.Black,
+		})
+	}
+	panel := func(msg string) ui.Component {
+		return ui.Container(ui.Rect(0, 0, 0, 0), &ui.ContainerOptions{
+			Border:    ui.StrokeBorder(color.Black, 4),
+			FillColor: color.RGBA{255, 0, 0, 50},
+			Flex: &ui.FlexOptions{
+				Justify:    ui.JustifyCenter,
+				AlignItems: ui.AlignCenter,
+			},
+		}, label(msg))
+	}
+
+	display.Add(ui.Grid(ui.Rect(0, 0, 1024, 1024), &ui.GridOptions{
+		Columns:   []ui.Track{ui.FixedTrack(256), ui.FractionTrack(1), ui.FractionTrack(1)},
+		Rows:      []ui.Track{ui.AutoTrack(), ui.FractionTrack(2), ui.FractionTrack(1)},
+		ColumnGap: 16,
+		RowGap:    16,
+		Margin:    ui.UniformQuad(32),
+		Padding:   ui.UniformQuad(16),
+		Border:    ui.StrokeBorder(color.Black, 8),
+	},
+		ui.GridCell(label("DASHBOARD"), &ui.GridCellOptions{ColumnSpan: 3, JustifySelf: ui.AlignCenter}),
+		ui.GridCell(panel("NAV"), &ui.GridCellOptions{Row: 1, RowSpan: 2}),
+		panel("CH1"),
+		panel("CH2"),
+		panel("CH3"),
+		panel("CH4"),
+	))
+
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
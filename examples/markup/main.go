@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Markup",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+
+	m, err := ui.LoadMarkupFile("layout.xml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	clicks := 0
+	count := m.Text("count")
+	m.OnClick("increment", func() {
+		clicks++
+		count.SetText(fmt.Sprintf("Clicked %d times", clicks))
+	})
+	m.OnClick("reset", func() {
+		clicks = 0
+		count.SetText("Clicked 0 times")
+	})
+	m.OnClick("dark", func() {
+		if m.Button("dark").Pressed() {
+			display.SetTheme(ui.LightTheme())
+		} else {
+			display.SetTheme(ui.DarkTheme())
+		}
+	})
+
+	display.Add(m.Root())
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
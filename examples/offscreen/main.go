@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+
+	"github.com/eliquious/ui"
+)
+
+// Renders a display off screen and saves the last frame. On Linux without a display run with xvfb-run.
+func main() {
+	os.Exit(ui.RunOffscreen(func() int {
+		display := ui.New(context.Background(), &ui.DisplaySettings{
+			Title:           "Offscreen",
+			Width:           256,
+			Height:          256,
+			BackgroundColor: color.White,
+		})
+		display.Add(ui.Container(ui.Rect(16, 16, 224, 224), &ui.ContainerOptions{
+			Border:    ui.StrokeBorder(color.Black, 4),
+			FillColor: color.RGBA{255, 0, 0, 50},
+		},
+			ui.Rectangle(ui.Rect(96, 96, 64, 64), &ui.RectangleOptions{
+				Border:  ui.StrokeBorder(color.Black, 2),
+				CenterX: true,
+				CenterY: true,
+			}),
+			ui.Circle(96, 96, 48, &ui.CircleOptions{
+				Stroke: ui.Stroke{Color: color.Black, Width: 2},
+			}),
+			ui.Text("Offscreen", 96, 24, &ui.TextOptions{
+				Font:     "arial.ttf",
+				FontSize: 24,
+				CenterX:  true,
+			}),
+		))
+
+		img, err := display.Render(1)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		f, err := os.Create("offscreen.png")
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+		defer f.Close()
+		if err := png.Encode(f, img); err != nil {
+			log.Print(err)
+			return 1
+		}
+		return 0
+	}))
+}
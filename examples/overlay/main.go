@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"image"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+// button creates a node for a button with a centered label.
+func button(label string, r image.Rectangle, onClick func()) *ui.Node {
+	face := func(fill ui.StateStyle) ui.Component {
+		return ui.Container(r, &ui.ContainerOptions{
+			FillColor: fill.Background,
+			Border:    ui.StrokeBorder(fill.Border, 1),
+			CenterX:   true,
+			CenterY:   true,
+		}, ui.Text(label, 0, 0, &ui.TextOptions{}))
+	}
+	widget := ui.DefaultTheme.Widget
+	b := ui.MomentaryButton(r, face(widget.Normal), face(widget.Hover), face(widget.Pressed),
+		func() ui.ButtonState { return ui.ButtonDown }, onClick)
+	return ui.NewNode(b).SetBounds(r)
+}
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Overlays",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+	overlays := display.Overlays()
+	status := ui.DynamicText(&ui.TextOptions{}).SetText("Right click for a context menu")
+	status.SetPosition(32, 320)
+
+	// right clicking anywhere opens a context menu
+	background := ui.NewNode(nil).SetBounds(ui.Rect(0, 0, screenWidth, screenHeight))
+	background.AddMouseListener(ui.MouseListenerFunc(func(evt *ui.PointerEvent) bool {
+		if evt.EventType != ui.MousePressEvent || evt.Button != ebiten.MouseButtonRight {
+			return false
+		}
+		overlays.ShowMenu(evt.X, evt.Y, []ui.MenuItem{
+			{Label: "Cut", OnSelect: func() { status.SetText("Cut") }},
+			{Label: "Copy", OnSelect: func() { status.SetText("Copy") }},
+			{Label: "Paste", Disabled: true},
+			{Label: "Select all", OnSelect: func() { status.SetText("Select all") }},
+		}, &ui.MenuOptions{Width: 120})
+		return true
+	}))
+
+	// the dialog is a modal popup with its own close button
+	var dialog *ui.Popup
+	content := ui.NewNode(ui.Container(ui.Rect(0, 0, 320, 160), &ui.ContainerOptions{
+		FillColor: ui.DefaultTheme.Palette.Surface,
+		Border:    ui.StrokeBorder(ui.DefaultTheme.Palette.Border, 1),
+	}, ui.Text("The rest of the screen is blocked.", 24, 32, &ui.TextOptions{})))
+	content.Add(button("Close", ui.Rect(200, 104, 96, 32), func() { dialog.Close() }))
+	open := button("Open dialog", ui.Rect(32, 32, 160, 32), func() {
+		dialog = overlays.ShowModal(content, &ui.PopupOptions{Size: image.Pt(320, 160)})
+	})
+
+	// the tooltip is shown while the mouse is over the button and flips above it near the bottom of the screen
+	var tooltip *ui.Popup
+	tip := ui.DynamicText(&ui.TextOptions{
+		BackgroundColor: ui.DefaultTheme.Palette.Surface,
+		Padding:         ui.Quad{Top: 4, Right: 8, Bottom: 4, Left: 8},
+	}).SetText("Shown in the tooltip layer")
+	hover := button("Hover me", ui.Rect(32, 336, 160, 32), func() {})
+	hover.AddMouseListener(ui.MouseListenerFunc(func(evt *ui.PointerEvent) bool {
+		switch evt.EventType {
+		case ui.MouseEnterEvent:
+			tooltip = overlays.ShowTooltip(tip, hover.ScreenBounds())
+		case ui.MouseLeaveEvent:
+			if tooltip != nil {
+				tooltip.Close()
+			}
+		}
+		return false
+	}))
+
+	display.Add(background, open, hover, status)
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
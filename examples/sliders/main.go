@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Sliders",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+
+	gain := ui.Slider(ui.Rect(32, 40, 256, 16), &ui.SliderOptions{
+		Max:       100,
+		Step:      1,
+		Value:     50,
+		ShowValue: true,
+	})
+	band := ui.RangeSlider(ui.Rect(32, 100, 256, 16), &ui.RangeSliderOptions{
+		Min:       20,
+		Max:       20000,
+		Step:      10,
+		Low:       200,
+		High:      8000,
+		ShowValue: true,
+	})
+	level := ui.Slider(ui.Rect(400, 40, 16, 256), &ui.SliderOptions{
+		Vertical:  true,
+		Value:     0.25,
+		Step:      0.05,
+		ShowValue: true,
+		Format:    "%.2f",
+	})
+
+	display.Add(gain, band, level)
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
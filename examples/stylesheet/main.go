@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+// Edit theme.json while the example is running to restyle the components.
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Style Sheet",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+
+	if _, err := display.WatchTheme("theme.json"); err != nil {
+		log.Fatal(err)
+	}
+
+	display.Add(ui.StyledContainer("panel", ui.Rect(32, 32, 448, 320), &ui.ContainerOptions{},
+		ui.StyledText("title", "Dashboard", 48, 48, &ui.TextOptions{}),
+		ui.StyledText("", "Styles are reloaded when theme.json changes.", 48, 96, &ui.TextOptions{}),
+		ui.StyledText("muted", "Try changing the colors or font sizes.", 48, 128, &ui.TextOptions{}),
+	))
+
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
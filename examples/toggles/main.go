@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Toggles",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+
+	status := ui.DynamicText(&ui.TextOptions{}).SetText("Speed: Slow")
+	status.SetPosition(32, 320)
+
+	grid := ui.Checkbox(32, 32, &ui.CheckboxOptions{Label: "Show grid", Checked: true})
+	locked := ui.Checkbox(32, 64, &ui.CheckboxOptions{Label: "Locked", Disabled: true})
+	dark := ui.Switch(32, 112, &ui.SwitchOptions{
+		Label: "Dark mode",
+		OnChange: func(on bool) {
+			if on {
+				display.SetTheme(ui.DarkTheme())
+			} else {
+				display.SetTheme(ui.LightTheme())
+			}
+		},
+	})
+	speed := ui.RadioGroup(32, 160, []string{"Slow", "Medium", "Fast"}, &ui.RadioGroupOptions{
+		OnChange: func(index int) {
+			status.SetText(fmt.Sprintf("Speed: %s", []string{"Slow", "Medium", "Fast"}[index]))
+		},
+	})
+
+	display.Add(grid, locked, dark, speed, status)
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/eliquious/ui"
+)
+
+const (
+	screenWidth, screenHeight = 512, 384
+)
+
+func main() {
+	ui.EnableHighDPI()
+
+	ctx := context.Background()
+	display := ui.New(ctx, &ui.DisplaySettings{
+		Title:  "Tooltips",
+		Width:  screenWidth,
+		Height: screenHeight,
+	})
+
+	grid := ui.Checkbox(32, 32, &ui.CheckboxOptions{Label: "Show grid"})
+	snap := ui.Checkbox(32, 64, &ui.CheckboxOptions{Label: "Snap to grid"})
+	volume := ui.Slider(ui.Rect(32, 112, 200, 24), &ui.SliderOptions{Max: 100, Value: 50})
+
+	// rectangles cannot be hit, so the tooltip needs bounds
+	swatch := ui.Rectangle(ui.Rect(32, 160, 64, 64), &ui.RectangleOptions{FillColor: ui.DefaultTheme.Palette.Primary})
+
+	// the tooltips near the edges are kept within the window
+	corner := ui.Checkbox(400, 344, &ui.CheckboxOptions{Label: "Corner"})
+
+	display.Add(
+		ui.WithTooltip(grid, "Draws a grid behind the canvas", &ui.TooltipOptions{}),
+		ui.WithTooltip(snap, "Moves shapes to the nearest grid line", &ui.TooltipOptions{Delay: time.Second}),
+		ui.WithTooltip(volume, "Volume", &ui.TooltipOptions{}),
+		ui.WithTooltip(swatch, "Primary color", &ui.TooltipOptions{Bounds: ui.Rect(32, 160, 64, 64)}),
+		ui.WithTooltip(corner, "This tooltip flips above the cursor", &ui.TooltipOptions{}),
+	)
+	if err := display.Show(); err != nil {
+		log.Fatal(err)
+	}
+}
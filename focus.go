@@ -0,0 +1,329 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Focusable is a component which can receive keyboard focus. Key and text input events are only dispatched to a
+// focusable component while it has focus.
+type Focusable interface {
+	Component
+	OnFocus()
+	OnBlur()
+}
+
+// TabOrderer can be implemented by a focusable component to change its position in the Tab traversal order.
+// Components with a lower tab index are visited first. Components without a tab index have an index of 0.
+type TabOrderer interface {
+	TabIndex() int
+}
+
+// Disabler can be implemented by a focusable component which can be disabled. Disabled components are skipped by Tab
+// traversal and are not focused by the mouse.
+type Disabler interface {
+	Disabled() bool
+}
+
+// Hittable is implemented by components which can report whether a point lies within them.
+type Hittable interface {
+	Contains(x, y int) bool
+}
+
+// focusLocator is implemented by components which contain focusable components that are not nodes, such as the
+// children of a container.
+type focusLocator interface {
+	focusableAt(x, y int) Focusable
+}
+
+// focusableAt returns the component if it is focusable and contains the point, or the focusable component it contains
+// at the point.
+func focusableAt(c Component, x, y int) Focusable {
+	if l, ok := c.(focusLocator); ok {
+		return l.focusableAt(x, y)
+	}
+	if f, ok := c.(Focusable); ok {
+		if h, ok := c.(Hittable); ok && h.Contains(x, y) {
+			return f
+		}
+	}
+	return nil
+}
+
+// NewFocusManager creates a new focus manager.
+func NewFocusManager() *FocusManager {
+	return &FocusManager{components: make([]Focusable, 0)}
+}
+
+// FocusManager tracks which component owns keyboard input and routes key and text input events to it.
+type FocusManager struct {
+	components []Focusable
+	focused    Focusable
+	scopes     []*FocusScope
+}
+
+// FocusScope is a set of components which focus is limited to, such as the components of a modal dialog.
+type FocusScope struct {
+	components []Focusable
+	focused    Focusable
+}
+
+// Add adds focusable components to the traversal order. While a scope is active, the components are added to the
+// scope.
+func (f *FocusManager) Add(c ...Focusable) {
+	if len(f.scopes) > 0 {
+		s := f.scopes[len(f.scopes)-1]
+		s.components = append(s.components, c...)
+		return
+	}
+	f.components = append(f.components, c...)
+}
+
+// Remove removes a component from the traversal order and from all scopes. If the component has focus, it is blurred.
+func (f *FocusManager) Remove(c Focusable) {
+	if f.focused == c {
+		f.Blur()
+	}
+	f.components = removeFocusable(f.components, c)
+	for i := 0; i < len(f.scopes); i++ {
+		f.scopes[i].components = removeFocusable(f.scopes[i].components, c)
+		if f.scopes[i].focused == c {
+			f.scopes[i].focused = nil
+		}
+	}
+}
+
+// PushScope limits focus to the components until the scope is popped or removed, which keeps keyboard input within a
+// modal dialog. The focused component is blurred and focused again when the scope is popped.
+func (f *FocusManager) PushScope(c ...Focusable) *FocusScope {
+	s := &FocusScope{components: append([]Focusable(nil), c...), focused: f.focused}
+	f.scopes = append(f.scopes, s)
+	f.Blur()
+	return s
+}
+
+// PopScope removes the most recent scope and restores the focus from before it was pushed.
+func (f *FocusManager) PopScope() {
+	if len(f.scopes) == 0 {
+		return
+	}
+	s := f.scopes[len(f.scopes)-1]
+	f.scopes = f.scopes[:len(f.scopes)-1]
+	f.Focus(s.focused)
+}
+
+// RemoveScope removes the scope even if scopes were pushed after it, such as a modal dialog which is closed while
+// another dialog is open above it. Removing the most recent scope is the same as PopScope. Otherwise the focus stays
+// in the active scope and the scope above the removed one restores the focus from before the removed scope.
+func (f *FocusManager) RemoveScope(s *FocusScope) {
+	for i := 0; i < len(f.scopes); i++ {
+		if f.scopes[i] != s {
+			continue
+		}
+		if i == len(f.scopes)-1 {
+			f.PopScope()
+			return
+		}
+		f.scopes[i+1].focused = s.focused
+		f.scopes = append(f.scopes[:i], f.scopes[i+1:]...)
+		return
+	}
+}
+
+// active returns the components of the active scope or all components if there is no scope.
+func (f *FocusManager) active() []Focusable {
+	if len(f.scopes) > 0 {
+		return f.scopes[len(f.scopes)-1].components
+	}
+	return f.components
+}
+
+// capturesKeyboard returns true while a scope is active, so the keyboard handlers of a display do not receive input
+// meant for a modal dialog.
+func (f *FocusManager) capturesKeyboard() bool {
+	return len(f.scopes) > 0
+}
+
+// Focused returns the component with focus or nil if no component has focus.
+func (f *FocusManager) Focused() Focusable {
+	return f.focused
+}
+
+// Focus gives focus to the component. The previously focused component is blurred.
+func (f *FocusManager) Focus(c Focusable) {
+	if f.focused == c {
+		return
+	}
+	f.Blur()
+
+	f.focused = c
+	if c != nil {
+		c.OnFocus()
+	}
+}
+
+// Blur removes focus from the focused component.
+func (f *FocusManager) Blur() {
+	if f.focused != nil {
+		c := f.focused
+		f.focused = nil
+		c.OnBlur()
+	}
+}
+
+// Next moves focus to the next component in the traversal order.
+func (f *FocusManager) Next() {
+	f.step(1)
+}
+
+// Previous moves focus to the previous component in the traversal order.
+func (f *FocusManager) Previous() {
+	f.step(-1)
+}
+
+func (f *FocusManager) step(dir int) {
+	order := f.order()
+	if len(order) == 0 {
+		return
+	}
+
+	// find the focused component
+	index := -1
+	for i := 0; i < len(order); i++ {
+		if order[i] == f.focused {
+			index = i
+			break
+		}
+	}
+
+	// start at either end when nothing has focus
+	if index < 0 {
+		if dir > 0 {
+			f.Focus(order[0])
+		} else {
+			f.Focus(order[len(order)-1])
+		}
+		return
+	}
+	f.Focus(order[(index+dir+len(order))%len(order)])
+}
+
+// order returns the enabled components sorted by tab index. Components with the same index remain in the order they were added.
+func (f *FocusManager) order() []Focusable {
+	components := f.active()
+	order := make([]Focusable, 0, len(components))
+	for i := 0; i < len(components); i++ {
+		if !disabled(components[i]) {
+			order = append(order, components[i])
+		}
+	}
+
+	tabIndex := func(c Focusable) int {
+		if t, ok := c.(TabOrderer); ok {
+			return t.TabIndex()
+		}
+		return 0
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return tabIndex(order[i]) < tabIndex(order[j])
+	})
+	return order
+}
+
+// OnKeyEvent handles Tab and Shift-Tab traversal and forwards all other key events to the focused component.
+func (f *FocusManager) OnKeyEvent(evt KeyEvent) {
+	if evt.Key == ebiten.KeyTab && evt.EventType != KeyReleaseEvent {
+		if evt.Modifiers.Has(ModShift) {
+			f.Previous()
+		} else {
+			f.Next()
+		}
+		return
+	}
+
+	if h, ok := f.focused.(KeyHandler); ok {
+		h.OnKeyEvent(evt)
+	}
+}
+
+// OnTextInput forwards typed characters to the focused component.
+func (f *FocusManager) OnTextInput(chars []rune) {
+	if h, ok := f.focused.(TextInputHandler); ok {
+		h.OnTextInput(chars)
+	}
+}
+
+// OnMouseEvent focuses the topmost hittable component under the mouse when a button is pressed. Pressing outside the
+// focused component blurs it. The point is in screen coordinates, so it only suits components which are drawn at their
+// own position on the screen; displays use HandleMouseEvent instead.
+func (f *FocusManager) OnMouseEvent(x, y int, evt MouseEvent) {
+	if evt.EventType != MousePressEvent {
+		return
+	}
+
+	// later components are drawn on top
+	components := f.active()
+	for i := len(components) - 1; i >= 0; i-- {
+		if h, ok := components[i].(Hittable); ok && h.Contains(x, y) && !disabled(components[i]) {
+			f.Focus(components[i])
+			return
+		}
+	}
+
+	if h, ok := f.focused.(Hittable); ok && !h.Contains(x, y) {
+		f.Blur()
+	}
+}
+
+// HandleMouseEvent focuses the focusable component of the node which was pressed, or of its nearest ancestor, when the
+// manager performs the default action of a scene. The nodes are hit tested in their own coordinates, so components
+// within positioned nodes, such as popups, are focused, as are the focusable children of containers. Pressing where no
+// component can take focus blurs the focused component.
+func (f *FocusManager) HandleMouseEvent(evt *PointerEvent) bool {
+	if evt.EventType != MousePressEvent {
+		return false
+	}
+	for n := evt.Target; n != nil; n = n.parent {
+		c, ok := n.component.(Focusable)
+		if l, isLocator := n.component.(focusLocator); isLocator {
+			c = l.focusableAt(n.local(evt.X, evt.Y))
+			ok = c != nil
+		}
+		if ok && f.contains(c) && !disabled(c) {
+			f.Focus(c)
+			return false
+		}
+	}
+	if _, ok := f.focused.(Hittable); ok {
+		f.Blur()
+	}
+	return false
+}
+
+// contains returns true if the component can take focus in the active scope.
+func (f *FocusManager) contains(c Focusable) bool {
+	components := f.active()
+	for i := 0; i < len(components); i++ {
+		if components[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// disabled returns true if the component is disabled.
+func disabled(c Focusable) bool {
+	d, ok := c.(Disabler)
+	return ok && d.Disabled()
+}
+
+// removeFocusable removes the component from the slice.
+func removeFocusable(components []Focusable, c Focusable) []Focusable {
+	for i := 0; i < len(components); i++ {
+		if components[i] == c {
+			return append(components[:i], components[i+1:]...)
+		}
+	}
+	return components
+}
@@ -0,0 +1,318 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+)
+
+// TrackSizing enumerates how a grid row or column is sized.
+type TrackSizing int
+
+// These are the available track sizings.
+const (
+
+	// TrackFixed sizes the track in pixels.
+	TrackFixed TrackSizing = iota
+
+	// TrackFraction shares the remaining space between fractional tracks.
+	TrackFraction
+
+	// TrackAuto sizes the track to the largest cell within it.
+	TrackAuto
+)
+
+// Track is the size of a grid row or column.
+type Track struct {
+	Sizing TrackSizing
+	Size   float64
+}
+
+// FixedTrack creates a track with a fixed size in pixels.
+func FixedTrack(px int) Track {
+	return Track{TrackFixed, float64(px)}
+}
+
+// FractionTrack creates a track which takes a fraction of the remaining space.
+func FractionTrack(fr float64) Track {
+	return Track{TrackFraction, fr}
+}
+
+// AutoTrack creates a track which is sized to its content.
+func AutoTrack() Track {
+	return Track{Sizing: TrackAuto}
+}
+
+// GridOptions stores the options for a grid. Cells are aligned with JustifyItems horizontally and AlignItems
+// vertically. AlignAuto stretches cells to fill their area.
+type GridOptions struct {
+	Columns      []Track
+	Rows         []Track
+	ColumnGap    int
+	RowGap       int
+	JustifyItems Align
+	AlignItems   Align
+
+	FillColor color.Color
+	Margin    Quad
+	Border    Border
+	Padding   Quad
+}
+
+// GridCellOptions stores the placement of a cell in a grid. A zero span is treated as 1 and a negative row or column as
+// 0. A zero width or height uses the preferred size of the component.
+type GridCellOptions struct {
+	Row, Column         int
+	RowSpan, ColumnSpan int
+	JustifySelf         Align
+	AlignSelf           Align
+	Width, Height       int
+	Margin              Quad
+}
+
+// GridCell wraps a component with its placement in a grid. Components which are not wrapped are placed in the next
+// free cell in row order.
+func GridCell(c Component, opts *GridCellOptions) Component {
+	return &gridCell{arranged{Component: c}, opts}
+}
+
+type gridCell struct {
+	arranged
+	opts *GridCellOptions
+}
+
+// Grid creates a container which lays out its children in rows and columns. A grid with an empty width or height is
+// sized to its content.
+func Grid(r image.Rectangle, opts *GridOptions, children ...Component) Component {
+	containerOpts := &ContainerOptions{
+		FillColor: opts.FillColor,
+		Margin:    opts.Margin,
+		Border:    opts.Border,
+		Padding:   opts.Padding,
+	}
+	return newContainer(r, containerOpts, func(bounds image.Rectangle, children []Component) []image.Rectangle {
+		return GridLayout(bounds, opts, gridCellOptions(opts, children))
+	}, children)
+}
+
+// gridCellOptions returns the placement and measured size of each child. Unwrapped children fill the free cells.
+func gridCellOptions(opts *GridOptions, children []Component) []GridCellOptions {
+	columns := len(opts.Columns)
+	if columns < 1 {
+		columns = 1
+	}
+
+	cells := make([]GridCellOptions, len(children))
+	occupied := make(map[image.Point]bool)
+	occupy := func(cell GridCellOptions) {
+		for row := cell.Row; row < cell.Row+cell.RowSpan; row++ {
+			for col := cell.Column; col < cell.Column+cell.ColumnSpan; col++ {
+				occupied[image.Pt(col, row)] = true
+			}
+		}
+	}
+	free := func(cell GridCellOptions) bool {
+		for row := cell.Row; row < cell.Row+cell.RowSpan; row++ {
+			for col := cell.Column; col < cell.Column+cell.ColumnSpan; col++ {
+				if occupied[image.Pt(col, row)] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	// explicitly placed cells
+	for i := 0; i < len(children); i++ {
+		c := children[i]
+		if g, ok := c.(*gridCell); ok {
+			cells[i] = *g.opts
+			c = g.Component
+		}
+		if cells[i].RowSpan < 1 {
+			cells[i].RowSpan = 1
+		}
+		if cells[i].ColumnSpan < 1 {
+			cells[i].ColumnSpan = 1
+		}
+		if cells[i].Row < 0 {
+			cells[i].Row = 0
+		}
+		if cells[i].Column < 0 {
+			cells[i].Column = 0
+		}
+
+		size := Measure(c, Constraints{}).Preferred
+		if cells[i].Width == 0 {
+			cells[i].Width = size.X
+		}
+		if cells[i].Height == 0 {
+			cells[i].Height = size.Y
+		}
+
+		if _, ok := children[i].(*gridCell); ok {
+			occupy(cells[i])
+		}
+	}
+
+	// automatically placed cells take the next position where the whole span is free and, unless the span is wider
+	// than the grid, fits within the columns
+	next := 0
+	for i := 0; i < len(children); i++ {
+		if _, ok := children[i].(*gridCell); ok {
+			continue
+		}
+		for {
+			cells[i].Row, cells[i].Column = next/columns, next%columns
+			fits := cells[i].Column == 0 || cells[i].Column+cells[i].ColumnSpan <= columns
+			if fits && free(cells[i]) {
+				break
+			}
+			next++
+		}
+		occupy(cells[i])
+	}
+	return cells
+}
+
+// GridLayout computes the rectangle of each cell within the bounds.
+func GridLayout(bounds image.Rectangle, opts *GridOptions, cells []GridCellOptions) []image.Rectangle {
+	columns := opts.Columns
+	rows := opts.Rows
+
+	// negative positions are placed in the first track
+	cells = append([]GridCellOptions(nil), cells...)
+	for i := 0; i < len(cells); i++ {
+		if cells[i].Row < 0 {
+			cells[i].Row = 0
+		}
+		if cells[i].Column < 0 {
+			cells[i].Column = 0
+		}
+	}
+
+	// add auto tracks for cells beyond the defined tracks
+	for _, cell := range cells {
+		span := cell.ColumnSpan
+		if span < 1 {
+			span = 1
+		}
+		for len(columns) < cell.Column+span {
+			columns = append(columns, AutoTrack())
+		}
+		span = cell.RowSpan
+		if span < 1 {
+			span = 1
+		}
+		for len(rows) < cell.Row+span {
+			rows = append(rows, AutoTrack())
+		}
+	}
+
+	colSizes := resolveTracks(columns, bounds.Dx(), opts.ColumnGap, cells, func(c GridCellOptions) (int, int, int) {
+		return c.Column, c.ColumnSpan, c.Width + c.Margin.Left + c.Margin.Right
+	})
+	rowSizes := resolveTracks(rows, bounds.Dy(), opts.RowGap, cells, func(c GridCellOptions) (int, int, int) {
+		return c.Row, c.RowSpan, c.Height + c.Margin.Top + c.Margin.Bottom
+	})
+
+	// track offsets
+	colOffsets := trackOffsets(colSizes, opts.ColumnGap)
+	rowOffsets := trackOffsets(rowSizes, opts.RowGap)
+
+	rects := make([]image.Rectangle, len(cells))
+	for i, cell := range cells {
+		colSpan, rowSpan := cell.ColumnSpan, cell.RowSpan
+		if colSpan < 1 {
+			colSpan = 1
+		}
+		if rowSpan < 1 {
+			rowSpan = 1
+		}
+
+		// area covered by the cell including the gaps it spans
+		area := image.Rect(
+			colOffsets[cell.Column],
+			rowOffsets[cell.Row],
+			colOffsets[cell.Column+colSpan-1]+colSizes[cell.Column+colSpan-1],
+			rowOffsets[cell.Row+rowSpan-1]+rowSizes[cell.Row+rowSpan-1],
+		)
+		area.Min.X += cell.Margin.Left
+		area.Min.Y += cell.Margin.Top
+		area.Max.X -= cell.Margin.Right
+		area.Max.Y -= cell.Margin.Bottom
+
+		justify := cell.JustifySelf
+		if justify == AlignAuto {
+			justify = opts.JustifyItems
+		}
+		align := cell.AlignSelf
+		if align == AlignAuto {
+			align = opts.AlignItems
+		}
+
+		x, w := alignInTrack(justify, area.Min.X, area.Dx(), cell.Width)
+		y, h := alignInTrack(align, area.Min.Y, area.Dy(), cell.Height)
+		rects[i] = Rect(bounds.Min.X+x, bounds.Min.Y+y, w, h)
+	}
+	return rects
+}
+
+// resolveTracks computes the size of each track. The span function returns the first track, the span and the size
+// of a cell along the axis.
+func resolveTracks(tracks []Track, avail, gap int, cells []GridCellOptions, span func(GridCellOptions) (int, int, int)) []int {
+	sizes := make([]int, len(tracks))
+	free := avail - gap*(len(tracks)-1)
+
+	var fractions float64
+	for i, t := range tracks {
+		switch t.Sizing {
+		case TrackFixed:
+			sizes[i] = int(t.Size)
+		case TrackAuto:
+			// size to the largest cell contained in the track
+			for _, c := range cells {
+				if start, n, size := span(c); start == i && n <= 1 && size > sizes[i] {
+					sizes[i] = size
+				}
+			}
+		case TrackFraction:
+			fractions += t.Size
+			continue
+		}
+		free -= sizes[i]
+	}
+
+	// share the remaining space between fractional tracks
+	if fractions > 0 && free > 0 {
+		for i, t := range tracks {
+			if t.Sizing == TrackFraction {
+				sizes[i] = int(float64(free) * t.Size / fractions)
+			}
+		}
+	}
+	return sizes
+}
+
+// trackOffsets returns the start of each track.
+func trackOffsets(sizes []int, gap int) []int {
+	offsets := make([]int, len(sizes))
+	offset := 0
+	for i, size := range sizes {
+		offsets[i] = offset
+		offset += size + gap
+	}
+	return offsets
+}
+
+// alignInTrack returns the position and size of a cell within the space. AlignAuto stretches the cell.
+func alignInTrack(align Align, start, space, size int) (int, int) {
+	switch align {
+	case AlignStart:
+		return start, size
+	case AlignEnd:
+		return start + space - size, size
+	case AlignCenter:
+		return start + (space-size)/2, size
+	}
+	return start, space
+}
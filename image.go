@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"image"
+
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -59,3 +61,20 @@ func (d *DynamicImageComponent) Display(ctx *DisplayContext) {
 	op.GeoM.Translate(dx, dy)
 	ctx.DrawImage(d.internal, op)
 }
+
+// Measure returns the size and position of the image.
+func (d *DynamicImageComponent) Measure(c Constraints) SizeHints {
+	if d.internal == nil {
+		return SizeHints{}
+	}
+	iw, ih := d.internal.Size()
+	hints := FixedSizeHints(iw, ih)
+	hints.Origin = image.Pt(int(d.opts.X), int(d.opts.Y))
+	if d.opts.CenterX {
+		hints.Origin.X -= iw / 2
+	}
+	if d.opts.CenterY {
+		hints.Origin.Y -= ih / 2
+	}
+	return hints
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// InputSource provides the state of the mouse and keyboard for the current tick. Update is called by the display once
+// at the start of every tick before the state is read.
+type InputSource interface {
+	Update()
+
+	CursorPosition() (int, int)
+	IsMouseButtonJustPressed(b ebiten.MouseButton) bool
+	IsMouseButtonJustReleased(b ebiten.MouseButton) bool
+	Wheel() (float64, float64)
+
+	IsKeyPressed(k ebiten.Key) bool
+	IsKeyJustPressed(k ebiten.Key) bool
+	IsKeyJustReleased(k ebiten.Key) bool
+	KeyPressDuration(k ebiten.Key) int
+	InputChars() []rune
+}
+
+// EbitenInput is the input source which reads the mouse and keyboard from ebiten.
+var EbitenInput InputSource = ebitenInput{}
+
+type ebitenInput struct{}
+
+func (ebitenInput) Update() {}
+
+func (ebitenInput) CursorPosition() (int, int) {
+	return ebiten.CursorPosition()
+}
+
+func (ebitenInput) IsMouseButtonJustPressed(b ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustPressed(b)
+}
+
+func (ebitenInput) IsMouseButtonJustReleased(b ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustReleased(b)
+}
+
+func (ebitenInput) Wheel() (float64, float64) {
+	return ebiten.Wheel()
+}
+
+func (ebitenInput) IsKeyPressed(k ebiten.Key) bool {
+	return ebiten.IsKeyPressed(k)
+}
+
+func (ebitenInput) IsKeyJustPressed(k ebiten.Key) bool {
+	return inpututil.IsKeyJustPressed(k)
+}
+
+func (ebitenInput) IsKeyJustReleased(k ebiten.Key) bool {
+	return inpututil.IsKeyJustReleased(k)
+}
+
+func (ebitenInput) KeyPressDuration(k ebiten.Key) int {
+	return inpututil.KeyPressDuration(k)
+}
+
+func (ebitenInput) InputChars() []rune {
+	return ebiten.InputChars()
+}
+
+// NewScriptedInput creates an input source which is driven by code instead of the mouse and keyboard.
+func NewScriptedInput() *ScriptedInput {
+	return &ScriptedInput{
+		buttons:  make(map[ebiten.MouseButton]int),
+		released: make(map[ebiten.MouseButton]bool),
+		keys:     make(map[ebiten.Key]int),
+		keysUp:   make(map[ebiten.Key]bool),
+		changed:  make(map[interface{}]bool),
+	}
+}
+
+// ScriptedInput is an input source for tests and replays. Changes such as MoveTo and Press take effect on the next
+// tick. A change to a button or key which already changed in the tick, or a move after a button changed, is queued
+// for the following tick with the changes after it, so Press followed by Release produces a click over two ticks.
+// Frames added with Script are played one per tick before any other pending changes are applied.
+type ScriptedInput struct {
+	x, y           int
+	buttons        map[ebiten.MouseButton]int
+	released       map[ebiten.MouseButton]bool
+	keys           map[ebiten.Key]int
+	keysUp         map[ebiten.Key]bool
+	wheelX, wheelY float64
+	chars          []rune
+
+	// changed holds the buttons and keys which changed in the current tick
+	changed map[interface{}]bool
+
+	pending []func() bool
+	script  []func(in *ScriptedInput)
+}
+
+// Script queues functions to run at the start of the following ticks, one per tick. A nil function is an idle tick.
+func (s *ScriptedInput) Script(frames ...func(in *ScriptedInput)) *ScriptedInput {
+	s.script = append(s.script, frames...)
+	return s
+}
+
+// Done returns true when all the scripted frames and pending changes have been played.
+func (s *ScriptedInput) Done() bool {
+	return len(s.script) == 0 && len(s.pending) == 0
+}
+
+// MoveTo moves the cursor. A move after a button changed in the same tick is queued for the next tick so the button
+// changes at the previous position.
+func (s *ScriptedInput) MoveTo(x, y int) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		if s.changed[scriptedButtons] {
+			return false
+		}
+		s.x, s.y = x, y
+		return true
+	})
+	return s
+}
+
+// Press presses the mouse button.
+func (s *ScriptedInput) Press(b ebiten.MouseButton) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		if !s.change(b) {
+			return false
+		}
+		if s.buttons[b] == 0 {
+			s.buttons[b] = 1
+		}
+		return true
+	})
+	return s
+}
+
+// Release releases the mouse button.
+func (s *ScriptedInput) Release(b ebiten.MouseButton) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		if !s.change(b) {
+			return false
+		}
+		if s.buttons[b] > 0 {
+			delete(s.buttons, b)
+			s.released[b] = true
+		}
+		return true
+	})
+	return s
+}
+
+// Scroll scrolls the mouse wheel.
+func (s *ScriptedInput) Scroll(dx, dy float64) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		s.wheelX += dx
+		s.wheelY += dy
+		return true
+	})
+	return s
+}
+
+// KeyDown presses the key.
+func (s *ScriptedInput) KeyDown(k ebiten.Key) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		if !s.change(k) {
+			return false
+		}
+		if s.keys[k] == 0 {
+			s.keys[k] = 1
+		}
+		return true
+	})
+	return s
+}
+
+// KeyUp releases the key.
+func (s *ScriptedInput) KeyUp(k ebiten.Key) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		if !s.change(k) {
+			return false
+		}
+		if s.keys[k] > 0 {
+			delete(s.keys, k)
+			s.keysUp[k] = true
+		}
+		return true
+	})
+	return s
+}
+
+// Type types the characters.
+func (s *ScriptedInput) Type(text string) *ScriptedInput {
+	s.pending = append(s.pending, func() bool {
+		s.chars = append(s.chars, []rune(text)...)
+		return true
+	})
+	return s
+}
+
+// scriptedButtons marks that a mouse button changed in the current tick.
+const scriptedButtons = "buttons"
+
+// change records a change to the button or key and returns false if it already changed in the current tick.
+func (s *ScriptedInput) change(buttonOrKey interface{}) bool {
+	if s.changed[buttonOrKey] {
+		return false
+	}
+	s.changed[buttonOrKey] = true
+	if _, ok := buttonOrKey.(ebiten.MouseButton); ok {
+		s.changed[scriptedButtons] = true
+	}
+	return true
+}
+
+// Update ages the held buttons and keys, clears the events of the last tick and applies the next changes.
+func (s *ScriptedInput) Update() {
+	for b := range s.buttons {
+		s.buttons[b]++
+	}
+	for k := range s.keys {
+		s.keys[k]++
+	}
+	s.released = make(map[ebiten.MouseButton]bool)
+	s.keysUp = make(map[ebiten.Key]bool)
+	s.wheelX, s.wheelY = 0, 0
+	s.chars = nil
+	s.changed = make(map[interface{}]bool)
+
+	// play the next scripted frame
+	if len(s.script) > 0 {
+		frame := s.script[0]
+		s.script = s.script[1:]
+		if frame != nil {
+			frame(s)
+		}
+	}
+
+	// apply the changes in order until one conflicts with a change of this tick
+	pending := s.pending
+	s.pending = nil
+	for i := 0; i < len(pending); i++ {
+		if !pending[i]() {
+			s.pending = append(pending[i:], s.pending...)
+			return
+		}
+	}
+}
+
+// CursorPosition returns the cursor position.
+func (s *ScriptedInput) CursorPosition() (int, int) {
+	return s.x, s.y
+}
+
+// IsMouseButtonJustPressed returns true if the button was pressed this tick.
+func (s *ScriptedInput) IsMouseButtonJustPressed(b ebiten.MouseButton) bool {
+	return s.buttons[b] == 1
+}
+
+// IsMouseButtonJustReleased returns true if the button was released this tick.
+func (s *ScriptedInput) IsMouseButtonJustReleased(b ebiten.MouseButton) bool {
+	return s.released[b]
+}
+
+// Wheel returns the wheel offset for this tick.
+func (s *ScriptedInput) Wheel() (float64, float64) {
+	return s.wheelX, s.wheelY
+}
+
+// IsKeyPressed returns true if the key is held.
+func (s *ScriptedInput) IsKeyPressed(k ebiten.Key) bool {
+	return s.keys[k] > 0
+}
+
+// IsKeyJustPressed returns true if the key was pressed this tick.
+func (s *ScriptedInput) IsKeyJustPressed(k ebiten.Key) bool {
+	return s.keys[k] == 1
+}
+
+// IsKeyJustReleased returns true if the key was released this tick.
+func (s *ScriptedInput) IsKeyJustReleased(k ebiten.Key) bool {
+	return s.keysUp[k]
+}
+
+// KeyPressDuration returns the number of ticks the key has been held.
+func (s *ScriptedInput) KeyPressDuration(k ebiten.Key) int {
+	return s.keys[k]
+}
+
+// InputChars returns the characters typed this tick.
+func (s *ScriptedInput) InputChars() []rune {
+	return s.chars
+}
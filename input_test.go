@@ -0,0 +1,53 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/eliquious/ui"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestScriptedInputClick(t *testing.T) {
+	in := ui.NewScriptedInput()
+	in.MoveTo(5, 5).Press(ebiten.MouseButtonLeft).MoveTo(9, 9).Release(ebiten.MouseButtonLeft)
+
+	// the press and the release happen on separate ticks
+	in.Update()
+	if !in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || in.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		t.Fatal("expected the press on the first tick")
+	}
+	if x, y := in.CursorPosition(); x != 5 || y != 5 {
+		t.Fatalf("expected the press at 5,5; got %d,%d", x, y)
+	}
+
+	in.Update()
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || !in.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		t.Fatal("expected the release on the second tick")
+	}
+	if x, y := in.CursorPosition(); x != 9 || y != 9 {
+		t.Fatalf("expected the release at 9,9; got %d,%d", x, y)
+	}
+	if !in.Done() {
+		t.Fatal("expected all the changes to be played")
+	}
+
+	in.Update()
+	if in.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		t.Fatal("expected the release to last one tick")
+	}
+}
+
+func TestScriptedInputKeys(t *testing.T) {
+	in := ui.NewScriptedInput()
+	in.KeyDown(ebiten.KeyA).KeyDown(ebiten.KeyB).KeyUp(ebiten.KeyA)
+
+	in.Update()
+	if !in.IsKeyJustPressed(ebiten.KeyA) || !in.IsKeyJustPressed(ebiten.KeyB) {
+		t.Fatal("expected both keys to be pressed on the first tick")
+	}
+
+	in.Update()
+	if !in.IsKeyJustReleased(ebiten.KeyA) || !in.IsKeyPressed(ebiten.KeyB) || in.KeyPressDuration(ebiten.KeyB) != 2 {
+		t.Fatal("expected A to be released while B is held on the second tick")
+	}
+}
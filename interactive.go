@@ -11,6 +11,17 @@ type Button interface {
 	MouseMoveHandler
 }
 
+// StatefulButton is a button which reports its state.
+type StatefulButton interface {
+	Button
+
+	// Pressed returns true if the button is pressed.
+	Pressed() bool
+
+	// MouseOver returns true if the mouse is over the button.
+	MouseOver() bool
+}
+
 // ButtonState represents a button's internal state.
 type ButtonState bool
 
@@ -23,7 +34,7 @@ const (
 // MomentaryButton creates an interactive component which responds to mouse events. The onPress function returns the state of the button that should be set after the function had completed.
 // Normally, the onPress function should always return true as the button state should remain pressed until the mouse button has released. However, during screen transitions, etc the
 // button state may require the button state to remain unpressed in true momentary fashion.
-func MomentaryButton(r image.Rectangle, defaultComponent Component, hover Component, pressed Component, onPress func() ButtonState, onRelease func()) Button {
+func MomentaryButton(r image.Rectangle, defaultComponent Component, hover Component, pressed Component, onPress func() ButtonState, onRelease func()) StatefulButton {
 	return &momentaryButton{r: r, defaultComponent: defaultComponent, hoverComponent: hover, pressedComponent: pressed, onPress: onPress, onRelease: onRelease}
 }
 
@@ -58,14 +69,19 @@ func (i *momentaryButton) Display(ctx *DisplayContext) {
 	i.defaultComponent.Display(ctx)
 }
 
-// Update is a no-op.
+// Update updates the components of the button.
 func (i *momentaryButton) Update(ctx *UpdateContext) error {
-	return nil
+	return updateButtonComponents(ctx, i.defaultComponent, i.hoverComponent, i.pressedComponent)
+}
+
+// Contains returns true if the point is inside the button.
+func (i *momentaryButton) Contains(x, y int) bool {
+	return x > i.r.Min.X && x < i.r.Max.X && y > i.r.Min.Y && y < i.r.Max.Y
 }
 
 // OnMouseEvent calls the onPress and onRelease handlers.
 func (i *momentaryButton) OnMouseEvent(x, y int, evt MouseEvent) {
-	if x > i.r.Min.X && x < i.r.Max.X && y > i.r.Min.Y && y < i.r.Max.Y {
+	if i.Contains(x, y) {
 		if evt.EventType == MousePressEvent {
 			i.pressed = !i.pressed
 
@@ -87,15 +103,11 @@ func (i *momentaryButton) OnMouseEvent(x, y int, evt MouseEvent) {
 
 // OnMouseMove toggles the mouse over effect.
 func (i *momentaryButton) OnMouseMove(x, y int) {
-	if x > i.r.Min.X && x < i.r.Max.X && y > i.r.Min.Y && y < i.r.Max.Y {
-		i.mouseOver = true
-	} else {
-		i.mouseOver = false
-	}
+	i.mouseOver = i.Contains(x, y)
 }
 
 // ToggleButton creates an interactive component which responds to mouse events and toggles state.
-func ToggleButton(r image.Rectangle, defaultComponent Component, hover Component, pressed Component, onPress func(), onRelease func()) Button {
+func ToggleButton(r image.Rectangle, defaultComponent Component, hover Component, pressed Component, onPress func(), onRelease func()) StatefulButton {
 	return &toggleButton{r: r, defaultComponent: defaultComponent, hoverComponent: hover, pressedComponent: pressed, onPress: onPress, onRelease: onRelease}
 }
 
@@ -130,14 +142,19 @@ func (i *toggleButton) Display(ctx *DisplayContext) {
 	i.defaultComponent.Display(ctx)
 }
 
-// Update is a no-op.
+// Update updates the components of the button.
 func (i *toggleButton) Update(ctx *UpdateContext) error {
-	return nil
+	return updateButtonComponents(ctx, i.defaultComponent, i.hoverComponent, i.pressedComponent)
+}
+
+// Contains returns true if the point is inside the button.
+func (i *toggleButton) Contains(x, y int) bool {
+	return x > i.r.Min.X && x < i.r.Max.X && y > i.r.Min.Y && y < i.r.Max.Y
 }
 
 // OnMouseEvent calls the onPress and onRelease handlers.
 func (i *toggleButton) OnMouseEvent(x, y int, evt MouseEvent) {
-	if x > i.r.Min.X && x < i.r.Max.X && y > i.r.Min.Y && y < i.r.Max.Y {
+	if i.Contains(x, y) {
 		if evt.EventType == MousePressEvent {
 
 			if i.onPress != nil && i.pressed == false {
@@ -152,9 +169,48 @@ func (i *toggleButton) OnMouseEvent(x, y int, evt MouseEvent) {
 
 // OnMouseMove toggles the mouse over effect.
 func (i *toggleButton) OnMouseMove(x, y int) {
-	if x > i.r.Min.X && x < i.r.Max.X && y > i.r.Min.Y && y < i.r.Max.Y {
-		i.mouseOver = true
-	} else {
-		i.mouseOver = false
+	i.mouseOver = i.Contains(x, y)
+}
+
+// Pressed returns true while the button is held down.
+func (i *momentaryButton) Pressed() bool {
+	return bool(i.pressed)
+}
+
+// MouseOver returns true if the mouse is over the button.
+func (i *momentaryButton) MouseOver() bool {
+	return i.mouseOver
+}
+
+// Pressed returns true if the button is toggled on.
+func (i *toggleButton) Pressed() bool {
+	return i.pressed
+}
+
+// MouseOver returns true if the mouse is over the button.
+func (i *toggleButton) MouseOver() bool {
+	return i.mouseOver
+}
+
+// Measure returns the size of the button.
+func (i *momentaryButton) Measure(c Constraints) SizeHints {
+	return BoundsSizeHints(i.r)
+}
+
+// Measure returns the size of the button.
+func (i *toggleButton) Measure(c Constraints) SizeHints {
+	return BoundsSizeHints(i.r)
+}
+
+// updateButtonComponents updates the components of a button which are set.
+func updateButtonComponents(ctx *UpdateContext, components ...Component) error {
+	for i := 0; i < len(components); i++ {
+		if components[i] == nil {
+			continue
+		}
+		if err := components[i].Update(ctx); err != nil {
+			return err
+		}
 	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/eliquious/ui"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestMomentaryButtonCallbacks(t *testing.T) {
+	var presses, releases int
+	btn := ui.MomentaryButton(ui.Rect(0, 0, 20, 20), ui.SimpleComponent(func(ctx *ui.DisplayContext) {}), nil, nil,
+		func() ui.ButtonState {
+			presses++
+			return ui.ButtonDown
+		},
+		func() { releases++ },
+	)
+
+	// presses outside the button are ignored
+	btn.OnMouseEvent(30, 30, ui.MouseEvent{Button: ebiten.MouseButtonLeft, EventType: ui.MousePressEvent})
+	if presses != 0 || btn.Pressed() {
+		t.Fatalf("expected the button to ignore a press outside of it; presses=%d", presses)
+	}
+
+	btn.OnMouseEvent(10, 10, ui.MouseEvent{Button: ebiten.MouseButtonLeft, EventType: ui.MousePressEvent})
+	if presses != 1 || !btn.Pressed() {
+		t.Fatalf("expected the press callback and a pressed button; presses=%d pressed=%t", presses, btn.Pressed())
+	}
+
+	// the release is handled even if the mouse has moved off of the button
+	btn.OnMouseEvent(30, 30, ui.MouseEvent{Button: ebiten.MouseButtonLeft, EventType: ui.MouseReleaseEvent})
+	if releases != 1 || btn.Pressed() {
+		t.Fatalf("expected the release callback and a released button; releases=%d pressed=%t", releases, btn.Pressed())
+	}
+}
+
+func TestMomentaryButtonStaysUp(t *testing.T) {
+	var releases int
+	btn := ui.MomentaryButton(ui.Rect(0, 0, 20, 20), ui.SimpleComponent(func(ctx *ui.DisplayContext) {}), nil, nil,
+		func() ui.ButtonState { return ui.ButtonUp },
+		func() { releases++ },
+	)
+
+	btn.OnMouseEvent(10, 10, ui.MouseEvent{Button: ebiten.MouseButtonLeft, EventType: ui.MousePressEvent})
+	btn.OnMouseEvent(10, 10, ui.MouseEvent{Button: ebiten.MouseButtonLeft, EventType: ui.MouseReleaseEvent})
+	if btn.Pressed() || releases != 0 {
+		t.Fatalf("expected a button which stays up to skip the release callback; releases=%d", releases)
+	}
+}
+
+func TestToggleButtonCallbacks(t *testing.T) {
+	var presses, releases int
+	btn := ui.ToggleButton(ui.Rect(0, 0, 20, 20), ui.SimpleComponent(func(ctx *ui.DisplayContext) {}), nil, nil,
+		func() { presses++ },
+		func() { releases++ },
+	)
+
+	press := ui.MouseEvent{Button: ebiten.MouseButtonLeft, EventType: ui.MousePressEvent}
+	btn.OnMouseEvent(10, 10, press)
+	if presses != 1 || releases != 0 || !btn.Pressed() {
+		t.Fatalf("expected the first press to toggle the button on; presses=%d releases=%d", presses, releases)
+	}
+	btn.OnMouseEvent(10, 10, press)
+	if presses != 1 || releases != 1 || btn.Pressed() {
+		t.Fatalf("expected the second press to toggle the button off; presses=%d releases=%d", presses, releases)
+	}
+}
+
+func TestButtonMouseOver(t *testing.T) {
+	btn := ui.ToggleButton(ui.Rect(0, 0, 20, 20), ui.SimpleComponent(func(ctx *ui.DisplayContext) {}), nil, nil, nil, nil)
+	btn.OnMouseMove(10, 10)
+	if !btn.MouseOver() {
+		t.Error("expected the mouse to be over the button")
+	}
+	btn.OnMouseMove(30, 10)
+	if btn.MouseOver() {
+		t.Error("expected the mouse to have left the button")
+	}
+}
@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// KeyEventType enumerates the type of key event.
+type KeyEventType int
+
+// String returns the string representation of the event type
+func (evt KeyEventType) String() string {
+	switch evt {
+	case KeyPressEvent:
+		return "KeyPressed"
+	case KeyReleaseEvent:
+		return "KeyReleased"
+	case KeyRepeatEvent:
+		return "KeyRepeated"
+	}
+	return "Unknown"
+}
+
+const (
+
+	// KeyPressEvent occurs when a key is pressed.
+	KeyPressEvent KeyEventType = iota
+
+	// KeyReleaseEvent occurs when a key is released.
+	KeyReleaseEvent
+
+	// KeyRepeatEvent occurs periodically while a key is held down.
+	KeyRepeatEvent
+)
+
+// Modifier is a bit mask of the modifier keys held during a key event.
+type Modifier int
+
+// These are the available modifier keys.
+const (
+	ModShift Modifier = 1 << iota
+	ModControl
+	ModAlt
+)
+
+// Has returns true if all the given modifiers are set.
+func (m Modifier) Has(mod Modifier) bool {
+	return m&mod == mod
+}
+
+// String returns the string representation of the modifiers.
+func (m Modifier) String() string {
+	var mods []string
+	if m.Has(ModShift) {
+		mods = append(mods, "Shift")
+	}
+	if m.Has(ModControl) {
+		mods = append(mods, "Control")
+	}
+	if m.Has(ModAlt) {
+		mods = append(mods, "Alt")
+	}
+	return strings.Join(mods, "+")
+}
+
+// KeyEvent stores the key, modifiers and event type.
+type KeyEvent struct {
+	Key       ebiten.Key
+	EventType KeyEventType
+	Modifiers Modifier
+}
+
+func (evt KeyEvent) String() string {
+	return fmt.Sprintf("KeyEvent: Key=%s Event=%s Modifiers=%s", evt.Key, evt.EventType, evt.Modifiers)
+}
+
+// KeyHandler is dispatched whenever key events occur.
+type KeyHandler interface {
+	OnKeyEvent(evt KeyEvent)
+}
+
+// TextInputHandler is dispatched whenever characters are typed.
+type TextInputHandler interface {
+	OnTextInput(chars []rune)
+}
+
+// These are the default key repeat settings in ticks.
+const (
+	DefaultKeyRepeatDelay    = 30
+	DefaultKeyRepeatInterval = 3
+)
+
+// DefaultKeyboardEventRegistry is a keyboard event registry for handlers outside of a display. Every display has its own
+// registry.
+var DefaultKeyboardEventRegistry = NewKeyboardEventRegistry()
+
+// NewKeyboardEventRegistry creates a new keyboard event registry.
+func NewKeyboardEventRegistry() *KeyboardEventRegistry {
+	return &KeyboardEventRegistry{
+		handlers:       make([]KeyHandler, 0),
+		textHandlers:   make([]TextInputHandler, 0),
+		repeatDelay:    DefaultKeyRepeatDelay,
+		repeatInterval: DefaultKeyRepeatInterval,
+		input:          EbitenInput,
+	}
+}
+
+// KeyboardEventRegistry stores all the key and text input handlers.
+type KeyboardEventRegistry struct {
+	handlers     []KeyHandler
+	textHandlers []TextInputHandler
+
+	repeatDelay    int
+	repeatInterval int
+	modifiers      Modifier
+	input          InputSource
+}
+
+// SetInput sets the source of the keyboard input.
+func (r *KeyboardEventRegistry) SetInput(input InputSource) {
+	r.input = input
+}
+
+// AddKeyHandler adds a key handler to the registry.
+func (r *KeyboardEventRegistry) AddKeyHandler(h KeyHandler) {
+	r.handlers = append(r.handlers, h)
+}
+
+// AddTextInputHandler adds a text input handler to the registry.
+func (r *KeyboardEventRegistry) AddTextInputHandler(h TextInputHandler) {
+	r.textHandlers = append(r.textHandlers, h)
+}
+
+// SetKeyRepeat sets the number of ticks a key must be held before repeating and the number of ticks between repeats.
+func (r *KeyboardEventRegistry) SetKeyRepeat(delay, interval int) {
+	if interval < 1 {
+		interval = 1
+	}
+	r.repeatDelay, r.repeatInterval = delay, interval
+}
+
+// Modifiers returns the modifier keys held during the last update.
+func (r *KeyboardEventRegistry) Modifiers() Modifier {
+	return r.modifiers
+}
+
+// Update gets the latest key events and dispatches them to the handlers
+func (r *KeyboardEventRegistry) Update() {
+	r.modifiers = 0
+	if r.input.IsKeyPressed(ebiten.KeyShift) {
+		r.modifiers |= ModShift
+	}
+	if r.input.IsKeyPressed(ebiten.KeyControl) {
+		r.modifiers |= ModControl
+	}
+	if r.input.IsKeyPressed(ebiten.KeyAlt) {
+		r.modifiers |= ModAlt
+	}
+
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		if r.input.IsKeyJustPressed(k) {
+			r.Dispatch(KeyEvent{Key: k, EventType: KeyPressEvent, Modifiers: r.modifiers})
+		} else if r.input.IsKeyJustReleased(k) {
+			r.Dispatch(KeyEvent{Key: k, EventType: KeyReleaseEvent, Modifiers: r.modifiers})
+		} else if d := r.input.KeyPressDuration(k); d > r.repeatDelay && (d-r.repeatDelay)%r.repeatInterval == 0 {
+			r.Dispatch(KeyEvent{Key: k, EventType: KeyRepeatEvent, Modifiers: r.modifiers})
+		}
+	}
+
+	if chars := r.input.InputChars(); len(chars) > 0 {
+		r.DispatchText(chars)
+	}
+}
+
+// keyboardCapturer is implemented by handlers which can take all keyboard input, such as the focus manager of a
+// display while a modal dialog is open.
+type keyboardCapturer interface {
+	capturesKeyboard() bool
+}
+
+// capturing returns true if the handler takes all keyboard input.
+func capturing(h interface{}) bool {
+	c, ok := h.(keyboardCapturer)
+	return ok && c.capturesKeyboard()
+}
+
+// Dispatch emits a key event to the key handlers. While a handler captures the keyboard, only it receives the event.
+func (r *KeyboardEventRegistry) Dispatch(evt KeyEvent) {
+	for i := 0; i < len(r.handlers); i++ {
+		if capturing(r.handlers[i]) {
+			r.handlers[i].OnKeyEvent(evt)
+			return
+		}
+	}
+	for i := 0; i < len(r.handlers); i++ {
+		r.handlers[i].OnKeyEvent(evt)
+	}
+}
+
+// DispatchText emits typed characters to the text input handlers. While a handler captures the keyboard, only it
+// receives the characters.
+func (r *KeyboardEventRegistry) DispatchText(chars []rune) {
+	for i := 0; i < len(r.textHandlers); i++ {
+		if capturing(r.textHandlers[i]) {
+			r.textHandlers[i].OnTextInput(chars)
+			return
+		}
+	}
+	for i := 0; i < len(r.textHandlers); i++ {
+		r.textHandlers[i].OnTextInput(chars)
+	}
+}
+
+// KeyHandlerFunc creates a KeyHandler from a function.
+func KeyHandlerFunc(h func(evt KeyEvent)) KeyHandler {
+	return &simpleKeyHandler{keyHandler: h}
+}
+
+// TextInputHandlerFunc creates a TextInputHandler from a function.
+func TextInputHandlerFunc(h func(chars []rune)) TextInputHandler {
+	return &simpleKeyHandler{textHandler: h}
+}
+
+type simpleKeyHandler struct {
+	keyHandler  func(evt KeyEvent)
+	textHandler func(chars []rune)
+}
+
+func (s *simpleKeyHandler) OnKeyEvent(evt KeyEvent) {
+	if s.keyHandler != nil {
+		s.keyHandler(evt)
+	}
+}
+
+func (s *simpleKeyHandler) OnTextInput(chars []rune) {
+	if s.textHandler != nil {
+		s.textHandler(chars)
+	}
+}
@@ -0,0 +1,94 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/eliquious/ui"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// keyField is a focusable component which records the keys it receives.
+type keyField struct {
+	ui.Component
+	keys []ebiten.Key
+}
+
+func newKeyField() *keyField {
+	return &keyField{Component: ui.SimpleComponent(func(ctx *ui.DisplayContext) {})}
+}
+
+func (f *keyField) OnFocus() {}
+
+func (f *keyField) OnBlur() {}
+
+func (f *keyField) OnKeyEvent(evt ui.KeyEvent) {
+	f.keys = append(f.keys, evt.Key)
+}
+
+func TestFocusScopeCapturesKeyboard(t *testing.T) {
+	focus := ui.NewFocusManager()
+	var keys []ebiten.Key
+	registry := ui.NewKeyboardEventRegistry()
+	registry.AddKeyHandler(focus)
+	registry.AddKeyHandler(ui.KeyHandlerFunc(func(evt ui.KeyEvent) {
+		keys = append(keys, evt.Key)
+	}))
+
+	// a modal scope keeps the key from the other handlers
+	field := newKeyField()
+	focus.PushScope(field)
+	focus.Next()
+	registry.Dispatch(ui.KeyEvent{Key: ebiten.KeyA, EventType: ui.KeyPressEvent})
+	if len(field.keys) != 1 || field.keys[0] != ebiten.KeyA {
+		t.Errorf("expected the focused field to receive A; got %v", field.keys)
+	}
+	if len(keys) != 0 {
+		t.Errorf("expected no keys outside of the scope; got %v", keys)
+	}
+
+	focus.PopScope()
+	registry.Dispatch(ui.KeyEvent{Key: ebiten.KeyB, EventType: ui.KeyPressEvent})
+	if len(keys) != 1 || keys[0] != ebiten.KeyB {
+		t.Errorf("expected B once the scope is popped; got %v", keys)
+	}
+	if len(field.keys) != 1 {
+		t.Errorf("expected the field to receive nothing after the scope; got %v", field.keys)
+	}
+}
+
+func TestModalsClosedOutOfOrder(t *testing.T) {
+	focus := ui.NewFocusManager()
+	overlays := ui.NewOverlayManager(ui.NewNode(nil), focus)
+	overlays.SetScreenSize(200, 200)
+	base := newKeyField()
+	focus.Add(base)
+	focus.Focus(base)
+
+	a, b := newKeyField(), newKeyField()
+	first := overlays.ShowModal(a, &ui.PopupOptions{})
+	second := overlays.ShowModal(b, &ui.PopupOptions{})
+	if focus.Focused() != b {
+		t.Fatal("expected the second modal to take focus")
+	}
+
+	// closing the dialog below keeps the keyboard in the dialog above
+	first.Close()
+	if focus.Focused() != b {
+		t.Fatal("expected the second modal to keep focus")
+	}
+	focus.Next()
+	focus.OnKeyEvent(ui.KeyEvent{Key: ebiten.KeyA, EventType: ui.KeyPressEvent})
+	if len(b.keys) != 1 || len(a.keys) != 0 || len(base.keys) != 0 {
+		t.Fatalf("expected only the second modal to receive A; got %v %v %v", base.keys, a.keys, b.keys)
+	}
+
+	// closing the last dialog restores the focus from before the first
+	second.Close()
+	if focus.Focused() != base {
+		t.Fatalf("expected the focus to return to the base component; got %v", focus.Focused())
+	}
+	focus.OnKeyEvent(ui.KeyEvent{Key: ebiten.KeyB, EventType: ui.KeyPressEvent})
+	if len(base.keys) != 1 {
+		t.Fatalf("expected the base component to receive B; got %v", base.keys)
+	}
+}
@@ -0,0 +1,337 @@
+package ui
+
+import (
+	"image"
+)
+
+// FlexDirection is the main axis along which a flex layout places items.
+type FlexDirection int
+
+// These are the available flex directions.
+const (
+	FlexRow FlexDirection = iota
+	FlexColumn
+)
+
+// Justify distributes the free space along the main axis.
+type Justify int
+
+// These are the available justifications.
+const (
+	JustifyStart Justify = iota
+	JustifyEnd
+	JustifyCenter
+	JustifySpaceBetween
+	JustifySpaceAround
+	JustifySpaceEvenly
+)
+
+// Align positions items along the cross axis.
+type Align int
+
+// These are the available alignments. AlignAuto uses the container's alignment for items and AlignStart for containers.
+const (
+	AlignAuto Align = iota
+	AlignStart
+	AlignEnd
+	AlignCenter
+	AlignStretch
+)
+
+// FlexOptions stores the options for a flex layout.
+type FlexOptions struct {
+	Direction  FlexDirection
+	Justify    Justify
+	AlignItems Align
+	Gap        int
+	Wrap       bool
+}
+
+// FlexItemOptions stores the options for an item in a flex layout. A zero width or height uses the preferred size of
+// the component and a zero minimum uses its minimum size. Items with a zero Shrink never shrink below their size.
+type FlexItemOptions struct {
+	Width, Height       int
+	MinWidth, MinHeight int
+	Grow                float64
+	Shrink              float64
+	AlignSelf           Align
+	Margin              Quad
+}
+
+// Arranger is implemented by components which size and position themselves within the rectangle assigned by a layout.
+// The rectangle is in the coordinate space of the parent. Other components are moved from the origin of their size
+// hints to the rectangle, so they may keep drawing at their own position.
+type Arranger interface {
+	Arrange(r image.Rectangle)
+}
+
+// FlexItem wraps a component with the options used to place it in a flex layout.
+func FlexItem(c Component, opts *FlexItemOptions) Component {
+	return &flexItem{arranged{Component: c}, opts}
+}
+
+type flexItem struct {
+	arranged
+	opts *FlexItemOptions
+}
+
+// arranged places a component within the rectangle assigned by a layout.
+type arranged struct {
+	Component
+	r image.Rectangle
+}
+
+// Arrange forwards the rectangle to the wrapped component.
+func (a *arranged) Arrange(r image.Rectangle) {
+	a.r = r
+	if c, ok := a.Component.(Arranger); ok {
+		c.Arrange(r)
+	}
+}
+
+// Display renders the wrapped component. Components which are not arrangers are translated to their rectangle.
+func (a *arranged) Display(ctx *DisplayContext) {
+	if _, ok := a.Component.(Arranger); ok {
+		a.Component.Display(ctx)
+		return
+	}
+	offset := arrangedOffset(a.Component, a.r)
+	a.Component.Display(ctx.Translate(float64(offset.X), float64(offset.Y)))
+}
+
+// Contains returns true if the wrapped component contains the point.
+func (a *arranged) Contains(x, y int) bool {
+	h, ok := a.Component.(Hittable)
+	return ok && h.Contains(a.point(x, y))
+}
+
+// OnMouseEvent forwards the event to the wrapped component.
+func (a *arranged) OnMouseEvent(x, y int, evt MouseEvent) {
+	if h, ok := a.Component.(MouseButtonHandler); ok {
+		x, y = a.point(x, y)
+		h.OnMouseEvent(x, y, evt)
+	}
+}
+
+// OnMouseMove forwards the move to the wrapped component.
+func (a *arranged) OnMouseMove(x, y int) {
+	if h, ok := a.Component.(MouseMoveHandler); ok {
+		h.OnMouseMove(a.point(x, y))
+	}
+}
+
+// focusableAt returns the wrapped component, or a focusable component within it, if it contains the point.
+func (a *arranged) focusableAt(x, y int) Focusable {
+	x, y = a.point(x, y)
+	return focusableAt(a.Component, x, y)
+}
+
+// components returns the wrapped component.
+func (a *arranged) components() []Component {
+	return []Component{a.Component}
+}
+
+// point converts a point from the coordinate space of the parent to that of the wrapped component.
+func (a *arranged) point(x, y int) (int, int) {
+	if _, ok := a.Component.(Arranger); ok {
+		return x, y
+	}
+	offset := arrangedOffset(a.Component, a.r)
+	return x - offset.X, y - offset.Y
+}
+
+// arrangedOffset returns the translation which moves a component from the position it draws at to the rectangle.
+func arrangedOffset(c Component, r image.Rectangle) image.Point {
+	return r.Min.Sub(Measure(c, Constraints{}).Origin)
+}
+
+// flexItemOptions returns the flex item options for a component including its measured size.
+func flexItemOptions(c Component) FlexItemOptions {
+	var opts FlexItemOptions
+	if f, ok := c.(*flexItem); ok {
+		opts = *f.opts
+		c = f.Component
+	}
+
+	// measure components when the size is not given
+	hints := Measure(c, Constraints{})
+	if opts.Width == 0 {
+		opts.Width = hints.Preferred.X
+	}
+	if opts.Height == 0 {
+		opts.Height = hints.Preferred.Y
+	}
+	if opts.MinWidth == 0 {
+		opts.MinWidth = hints.Min.X
+	}
+	if opts.MinHeight == 0 {
+		opts.MinHeight = hints.Min.Y
+	}
+	return opts
+}
+
+// FlexLayout computes the rectangle of each item within the bounds.
+func FlexLayout(bounds image.Rectangle, opts *FlexOptions, items []FlexItemOptions) []image.Rectangle {
+	row := opts.Direction == FlexRow
+
+	// main and cross axis accessors
+	mainSize := func(w, h int) int {
+		if row {
+			return w
+		}
+		return h
+	}
+	crossSize := func(w, h int) int {
+		if row {
+			return h
+		}
+		return w
+	}
+	mainMargin := func(q Quad) (int, int) {
+		if row {
+			return q.Left, q.Right
+		}
+		return q.Top, q.Bottom
+	}
+	crossMargin := func(q Quad) (int, int) {
+		if row {
+			return q.Top, q.Bottom
+		}
+		return q.Left, q.Right
+	}
+
+	availMain := mainSize(bounds.Dx(), bounds.Dy())
+	availCross := crossSize(bounds.Dx(), bounds.Dy())
+
+	// outer size of each item including margins
+	outer := make([]int, len(items))
+	for i, item := range items {
+		before, after := mainMargin(item.Margin)
+		outer[i] = mainSize(item.Width, item.Height) + before + after
+	}
+
+	// break items into lines
+	type flexLine struct {
+		start, end int
+		cross      int
+	}
+	lines := make([]flexLine, 0, 1)
+	start, used := 0, 0
+	for i := 0; i < len(items); i++ {
+		gap := 0
+		if i > start {
+			gap = opts.Gap
+		}
+		if opts.Wrap && i > start && used+gap+outer[i] > availMain {
+			lines = append(lines, flexLine{start: start, end: i})
+			start, used, gap = i, 0, 0
+		}
+		used += gap + outer[i]
+	}
+	lines = append(lines, flexLine{start: start, end: len(items)})
+
+	rects := make([]image.Rectangle, len(items))
+	sizes := make([]int, len(items))
+	crossOffset := 0
+	for l := range lines {
+		line := &lines[l]
+		n := line.end - line.start
+
+		// resolve main sizes with grow and shrink
+		free := availMain - opts.Gap*(n-1)
+		var totalGrow, totalShrink float64
+		for i := line.start; i < line.end; i++ {
+			free -= outer[i]
+			sizes[i] = mainSize(items[i].Width, items[i].Height)
+			totalGrow += items[i].Grow
+			totalShrink += items[i].Shrink * float64(sizes[i])
+		}
+		if free > 0 && totalGrow > 0 {
+			remaining := free
+			for i := line.start; i < line.end; i++ {
+				grow := int(float64(free) * items[i].Grow / totalGrow)
+				sizes[i] += grow
+				remaining -= grow
+			}
+			free = remaining
+		} else if free < 0 && totalShrink > 0 {
+			overflow := -free
+			for i := line.start; i < line.end; i++ {
+				shrink := int(float64(overflow) * items[i].Shrink * float64(sizes[i]) / totalShrink)
+				if min := mainSize(items[i].MinWidth, items[i].MinHeight); sizes[i]-shrink < min {
+					shrink = sizes[i] - min
+				}
+				if shrink < 0 {
+					shrink = 0
+				}
+				sizes[i] -= shrink
+				free += shrink
+			}
+		}
+
+		// cross size of the line
+		if len(lines) == 1 && !opts.Wrap {
+			line.cross = availCross
+		} else {
+			for i := line.start; i < line.end; i++ {
+				before, after := crossMargin(items[i].Margin)
+				if c := crossSize(items[i].Width, items[i].Height) + before + after; c > line.cross {
+					line.cross = c
+				}
+			}
+		}
+
+		// justify the free space
+		offset, spacing := 0, opts.Gap
+		if free > 0 && n > 0 {
+			switch opts.Justify {
+			case JustifyEnd:
+				offset = free
+			case JustifyCenter:
+				offset = free / 2
+			case JustifySpaceBetween:
+				if n > 1 {
+					spacing += free / (n - 1)
+				}
+			case JustifySpaceAround:
+				offset = free / (2 * n)
+				spacing += free / n
+			case JustifySpaceEvenly:
+				offset = free / (n + 1)
+				spacing += free / (n + 1)
+			}
+		}
+
+		// place items
+		for i := line.start; i < line.end; i++ {
+			mainBefore, mainAfter := mainMargin(items[i].Margin)
+			crossBefore, crossAfter := crossMargin(items[i].Margin)
+
+			align := items[i].AlignSelf
+			if align == AlignAuto {
+				align = opts.AlignItems
+			}
+			cross := crossSize(items[i].Width, items[i].Height)
+			space := line.cross - crossBefore - crossAfter
+			pos := crossBefore
+			switch align {
+			case AlignEnd:
+				pos += space - cross
+			case AlignCenter:
+				pos += (space - cross) / 2
+			case AlignStretch:
+				cross = space
+			}
+
+			offset += mainBefore
+			if row {
+				rects[i] = Rect(bounds.Min.X+offset, bounds.Min.Y+crossOffset+pos, sizes[i], cross)
+			} else {
+				rects[i] = Rect(bounds.Min.X+crossOffset+pos, bounds.Min.Y+offset, cross, sizes[i])
+			}
+			offset += sizes[i] + mainAfter + spacing
+		}
+		crossOffset += line.cross + opts.Gap
+	}
+	return rects
+}
@@ -0,0 +1,694 @@
+package ui
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // decode jpeg images
+	_ "image/png"  // decode png images
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Markup is a scene graph built from a markup document. Every element becomes a node positioned relative to its
+// parent, so the root can be added to a display directly. Elements with an id can be looked up to change them and to
+// bind callbacks.
+//
+// The document is XML or JSON. The root element is "ui" and the elements are "container", "rect", "text", "image"
+// and "button". Attributes are the same in both formats:
+//
+//	<ui>
+//	  <container id="panel" x="32" y="32" width="320" height="240" class="panel" padding="8" layout="column" gap="8">
+//	    <text id="title" style="title">Dashboard</text>
+//	    <rect width="304" height="2" fill="#3399ff"/>
+//	    <button id="refresh" width="96" height="32">Refresh</button>
+//	  </container>
+//	</ui>
+//
+// In JSON every element is an object with a "type", its attributes, an optional "text" and an optional array of
+// "children".
+//
+// Every element accepts id, class, x, y and z. Containers and rects accept width, height, fill, borderColor,
+// borderWidth and margin and containers also accept padding, layout ("row" or "column"), justify, align, gap and wrap
+// to place their children with a flex layout. The children of a container are placed within its margin, border and
+// padding, which follow the "Container" rules of the theme for its class. Children of a flex container accept grow,
+// shrink and alignSelf. Text accepts font, fontSize, style, textColor, background, padding, centerX and centerY.
+// Images accept src, which is relative to the document file. Buttons accept width, height, label, font, fontSize,
+// textColor, fill, hoverFill, pressedFill, borderColor and toggle. Colors are hex strings and quads are one, two or
+// four numbers in the order of CSS.
+type Markup struct {
+	root     *Node
+	nodes    map[string]*Node
+	handlers map[string]func()
+}
+
+// LoadMarkup builds the scene graph from an XML document.
+func LoadMarkup(r io.Reader) (*Markup, error) {
+	var n markupNode
+	if err := xml.NewDecoder(r).Decode(&n); err != nil {
+		return nil, err
+	}
+	return buildMarkup(&n, "")
+}
+
+// LoadMarkupJSON builds the scene graph from a JSON document.
+func LoadMarkupJSON(r io.Reader) (*Markup, error) {
+	var n markupNode
+	if err := json.NewDecoder(r).Decode(&n); err != nil {
+		return nil, err
+	}
+	return buildMarkup(&n, "")
+}
+
+// LoadMarkupFile builds the scene graph from a document file. Files with a .json extension are read as JSON and all
+// other files as XML.
+func LoadMarkupFile(path string) (*Markup, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var n markupNode
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.NewDecoder(f).Decode(&n)
+	} else {
+		err = xml.NewDecoder(f).Decode(&n)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	m, err := buildMarkup(&n, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return m, nil
+}
+
+// Root returns the root node of the scene graph.
+func (m *Markup) Root() *Node {
+	return m.root
+}
+
+// Node returns the node of the element with the id or nil if there is none.
+func (m *Markup) Node(id string) *Node {
+	return m.nodes[id]
+}
+
+// Component returns the component of the element with the id or nil if there is none.
+func (m *Markup) Component(id string) Component {
+	if n := m.nodes[id]; n != nil {
+		return n.Component()
+	}
+	return nil
+}
+
+// Button returns the button with the id or nil if the element is not a button.
+func (m *Markup) Button(id string) StatefulButton {
+	b, _ := m.Component(id).(StatefulButton)
+	return b
+}
+
+// Text returns the text component with the id or nil if the element is not a text element. Text elements with an id
+// are dynamic so their text can be changed.
+func (m *Markup) Text(id string) *DynamicTextComponent {
+	t, _ := m.Component(id).(*DynamicTextComponent)
+	return t
+}
+
+// OnClick binds the function to the button with the id. It is called when the button is released with the mouse over
+// it or, for toggle buttons, whenever the button is pressed.
+func (m *Markup) OnClick(id string, fn func()) error {
+	if m.Button(id) == nil {
+		return fmt.Errorf("no button with id %q", id)
+	}
+	m.handlers[id] = fn
+	return nil
+}
+
+// click calls the function bound to the button.
+func (m *Markup) click(id string) {
+	if fn := m.handlers[id]; fn != nil {
+		fn()
+	}
+}
+
+// markupNode is an element of a markup document.
+type markupNode struct {
+	Type     string
+	Attrs    map[string]string
+	Text     string
+	Children []*markupNode
+}
+
+// UnmarshalXML decodes the element and its children.
+func (n *markupNode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n.Type = start.Name.Local
+	n.Attrs = make(map[string]string)
+	for _, a := range start.Attr {
+		n.Attrs[a.Name.Local] = a.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			c := &markupNode{}
+			if err := c.UnmarshalXML(d, t); err != nil {
+				return err
+			}
+			n.Children = append(n.Children, c)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			n.Text = strings.TrimSpace(text.String())
+			return nil
+		}
+	}
+}
+
+// UnmarshalJSON decodes the element and its children. Numbers, booleans and arrays of numbers are converted to the
+// same strings used by XML attributes.
+func (n *markupNode) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	n.Attrs = make(map[string]string)
+	for k, v := range raw {
+		var err error
+		switch k {
+		case "type":
+			err = json.Unmarshal(v, &n.Type)
+		case "text":
+			err = json.Unmarshal(v, &n.Text)
+		case "children":
+			err = json.Unmarshal(v, &n.Children)
+		default:
+			var value interface{}
+			if err = json.Unmarshal(v, &value); err == nil {
+				n.Attrs[k], err = markupString(value)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %s", k, err)
+		}
+	}
+	return nil
+}
+
+// markupString converts a JSON value to an attribute string.
+func markupString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i := 0; i < len(v); i++ {
+			s, err := markupString(v[i])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, " "), nil
+	}
+	return "", fmt.Errorf("unsupported value: %v", v)
+}
+
+// markupBuilder builds the scene graph of a document.
+type markupBuilder struct {
+	m   *Markup
+	dir string
+}
+
+// buildMarkup builds the scene graph from the root element.
+func buildMarkup(root *markupNode, dir string) (*Markup, error) {
+	m := &Markup{nodes: make(map[string]*Node), handlers: make(map[string]func())}
+	b := &markupBuilder{m: m, dir: dir}
+
+	if root.Type != "ui" {
+		n, err := b.build(root)
+		if err != nil {
+			return nil, err
+		}
+		m.root = NewNode(nil).Add(n)
+		return m, nil
+	}
+
+	m.root = NewNode(nil)
+	for i := 0; i < len(root.Children); i++ {
+		n, err := b.build(root.Children[i])
+		if err != nil {
+			return nil, err
+		}
+		m.root.Add(n)
+	}
+	return m, nil
+}
+
+// build creates the node for the element and its children.
+func (b *markupBuilder) build(e *markupNode) (*Node, error) {
+	a := &markupAttrs{e: e}
+
+	var n *Node
+	switch e.Type {
+	case "container":
+		n = b.container(a)
+	case "rect":
+		n = b.rect(a)
+	case "text":
+		n = b.text(a)
+	case "image":
+		n = b.image(a)
+	case "button":
+		n = b.button(a)
+	default:
+		return nil, fmt.Errorf("unknown element: %q", e.Type)
+	}
+	n.SetPosition(float64(a.int("x")), float64(a.int("y")))
+	n.SetZIndex(a.int("z"))
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	if id := a.str("id"); id != "" {
+		if _, ok := b.m.nodes[id]; ok {
+			return nil, fmt.Errorf("duplicate id: %q", id)
+		}
+		b.m.nodes[id] = n
+	}
+
+	if len(e.Children) > 0 && e.Type != "container" {
+		return nil, fmt.Errorf("%s: element cannot have children", e.Type)
+	}
+	children := make([]*Node, len(e.Children))
+	for i := 0; i < len(e.Children); i++ {
+		c, err := b.build(e.Children[i])
+		if err != nil {
+			return nil, err
+		}
+		n.Add(c)
+		children[i] = c
+	}
+	if c, ok := n.Component().(*markupContainer); ok {
+		return n, b.layout(c, children, a)
+	}
+	return n, a.err
+}
+
+// container creates a node with a container component. The container is hit within its size so it blocks the nodes
+// below it.
+func (b *markupBuilder) container(a *markupAttrs) *Node {
+	c := &markupContainer{
+		r:     Rect(0, 0, a.int("width"), a.int("height")),
+		class: a.str("class"),
+		opts: &ContainerOptions{
+			FillColor: a.color("fill"),
+			Margin:    a.quad("margin"),
+			Border:    StrokeBorder(a.color("borderColor"), a.int("borderWidth")),
+			Padding:   a.quad("padding"),
+		},
+	}
+	return NewNode(c).SetBounds(c.r)
+}
+
+// rect creates a node with a rectangle. A rect with a class is styled by the "Rectangle" rules of the theme.
+func (b *markupBuilder) rect(a *markupAttrs) *Node {
+	r := Rect(0, 0, a.int("width"), a.int("height"))
+	opts := &RectangleOptions{
+		FillColor: a.color("fill"),
+		Margin:    a.quad("margin"),
+		Border:    StrokeBorder(a.color("borderColor"), a.int("borderWidth")),
+	}
+	if class := a.str("class"); class != "" {
+		return NewNode(StyledRectangle(class, r, opts))
+	}
+	return NewNode(Rectangle(r, opts))
+}
+
+// text creates a node with a text component. Text with a class is styled by the "Text" rules of the theme. Text with
+// an id is dynamic so its text can be changed.
+func (b *markupBuilder) text(a *markupAttrs) *Node {
+	opts := &TextOptions{
+		Font:            a.str("font"),
+		FontSize:        a.float("fontSize"),
+		Style:           a.textStyle("style"),
+		TextColor:       a.color("textColor"),
+		BackgroundColor: a.color("background"),
+		Padding:         a.quad("padding"),
+		CenterX:         a.bool("centerX"),
+		CenterY:         a.bool("centerY"),
+	}
+	msg := a.e.Text
+
+	if a.str("id") != "" {
+		return NewNode(StyledDynamicText(a.str("class"), opts).SetText(msg))
+	}
+	if class := a.str("class"); class != "" {
+		return NewNode(StyledText(class, msg, 0, 0, opts))
+	}
+	return NewNode(Text(msg, 0, 0, opts))
+}
+
+// image creates a node with an image loaded from a file.
+func (b *markupBuilder) image(a *markupAttrs) *Node {
+	src := a.str("src")
+	if src != "" && b.dir != "" && !filepath.IsAbs(src) {
+		src = filepath.Join(b.dir, src)
+	}
+	img, _, err := ebitenutil.NewImageFromFile(src)
+	if err != nil && a.err == nil {
+		a.err = fmt.Errorf("image: %s", err)
+	}
+	return NewNode(Image(img, &ImageOptions{CenterX: a.bool("centerX"), CenterY: a.bool("centerY")}))
+}
+
+// button creates a node with a button drawn in the widget colors of the theme. The fill colors and text color override
+// the theme. The button calls the function bound with OnClick.
+func (b *markupBuilder) button(a *markupAttrs) *Node {
+	id := a.str("id")
+	r := Rect(0, 0, a.int("width"), a.int("height"))
+	label := a.str("label")
+	if label == "" {
+		label = a.e.Text
+	}
+	textOpts := &TextOptions{
+		Font:      a.str("font"),
+		FontSize:  a.float("fontSize"),
+		TextColor: a.color("textColor"),
+		CenterX:   true,
+		CenterY:   true,
+	}
+	border := a.color("borderColor")
+	face := func(fill color.Color, state func(t *Theme) StateStyle) Component {
+		return Themed(func(t *Theme) Component {
+			s := state(t)
+			o := textOpts.resolve(t)
+			if textOpts.TextColor == nil {
+				o.TextColor = s.Foreground
+			}
+			bg, stroke := fill, border
+			if bg == nil {
+				bg = s.Background
+			}
+			if stroke == nil {
+				stroke = s.Border
+			}
+			return StackedComponent(
+				Rectangle(r, &RectangleOptions{FillColor: bg, Border: StrokeBorder(stroke, t.BorderWidth)}),
+				Text(label, r.Dx()/2, r.Dy()/2, o),
+			)
+		})
+	}
+	normal := face(a.color("fill"), func(t *Theme) StateStyle { return t.Widget.Normal })
+	hover := face(a.color("hoverFill"), func(t *Theme) StateStyle { return t.Widget.Hover })
+	pressed := face(a.color("pressedFill"), func(t *Theme) StateStyle { return t.Widget.Pressed })
+
+	var btn StatefulButton
+	if a.bool("toggle") {
+		click := func() { b.m.click(id) }
+		btn = ToggleButton(r, normal, hover, pressed, click, click)
+	} else {
+		btn = MomentaryButton(r, normal, hover, pressed, func() ButtonState { return ButtonDown }, func() {
+			if btn.MouseOver() {
+				b.m.click(id)
+			}
+		})
+	}
+	return NewNode(btn)
+}
+
+// layout sets the children of a container and their flex layout.
+func (b *markupBuilder) layout(c *markupContainer, children []*Node, a *markupAttrs) error {
+	c.children = children
+	c.origins = make([]image.Point, len(children))
+	for i := 0; i < len(children); i++ {
+		x, y := children[i].Position()
+		c.origins[i] = image.Pt(int(x), int(y))
+	}
+
+	if dir := a.str("layout"); dir != "" {
+		c.flex = &FlexOptions{
+			Justify:    a.justify("justify"),
+			AlignItems: a.align("align"),
+			Gap:        a.int("gap"),
+			Wrap:       a.bool("wrap"),
+		}
+		switch dir {
+		case "row":
+			c.flex.Direction = FlexRow
+		case "column":
+			c.flex.Direction = FlexColumn
+		default:
+			a.fail("layout", dir)
+		}
+
+		c.items = make([]FlexItemOptions, len(children))
+		for i := 0; i < len(children); i++ {
+			ca := &markupAttrs{e: a.e.Children[i]}
+			size := children[i].Bounds().Size()
+			if size == (image.Point{}) {
+				size = Measure(children[i].Component(), Constraints{}).Preferred
+			}
+			c.items[i] = FlexItemOptions{
+				Width:     size.X,
+				Height:    size.Y,
+				Grow:      ca.float("grow"),
+				Shrink:    ca.float("shrink"),
+				AlignSelf: ca.align("alignSelf"),
+			}
+			if ca.err != nil {
+				return ca.err
+			}
+		}
+	}
+
+	c.arrange(DefaultTheme)
+	return a.err
+}
+
+// markupContainer draws a container element and places the nodes of its children within the interior of the
+// container, either at their own coordinates or with a flex layout. The class is applied with the rules of the theme
+// of the display, so the children move when a theme changes the padding.
+type markupContainer struct {
+	r     image.Rectangle
+	class string
+	opts  *ContainerOptions
+	flex  *FlexOptions
+	items []FlexItemOptions
+
+	children []*Node
+	origins  []image.Point
+	theme    *Theme
+	box      Component
+}
+
+// arrange builds the container from the theme and positions the children within its interior.
+func (c *markupContainer) arrange(t *Theme) {
+	c.theme = t
+	opts := c.opts
+	if c.class != "" {
+		opts = t.Style("Container", c.class).Container(opts)
+	}
+	c.box = Container(c.r, opts)
+
+	left := opts.Margin.Left + opts.Border.Left.Width + opts.Padding.Left
+	top := opts.Margin.Top + opts.Border.Top.Width + opts.Padding.Top
+	right := opts.Margin.Right + opts.Border.Right.Width + opts.Padding.Right
+	bottom := opts.Margin.Bottom + opts.Border.Bottom.Width + opts.Padding.Bottom
+	interior := image.Rect(left, top, c.r.Dx()-right, c.r.Dy()-bottom)
+
+	var rects []image.Rectangle
+	if c.flex != nil {
+		rects = FlexLayout(interior, c.flex, c.items)
+	}
+	for i := 0; i < len(c.children); i++ {
+		p := c.origins[i].Add(interior.Min)
+		if rects != nil {
+			p = c.origins[i].Add(rects[i].Min)
+		}
+		c.children[i].SetPosition(float64(p.X), float64(p.Y))
+	}
+}
+
+// Update arranges the container again when the theme changes and updates it.
+func (c *markupContainer) Update(ctx *UpdateContext) error {
+	if t := ThemeFromContext(ctx.Context()); t != c.theme {
+		c.arrange(t)
+	}
+	return c.box.Update(ctx)
+}
+
+// Display renders the container. The children are drawn by their nodes.
+func (c *markupContainer) Display(ctx *DisplayContext) {
+	c.box.Display(ctx)
+}
+
+// Measure returns the size of the container.
+func (c *markupContainer) Measure(cons Constraints) SizeHints {
+	return BoundsSizeHints(c.r)
+}
+
+// markupAttrs parses the attributes of an element. The first error is kept so the attributes can be read in sequence
+// and checked once.
+type markupAttrs struct {
+	e   *markupNode
+	err error
+}
+
+// fail records an invalid attribute value.
+func (a *markupAttrs) fail(name, value string) {
+	if a.err == nil {
+		a.err = fmt.Errorf("%s: invalid %s: %q", a.e.Type, name, value)
+	}
+}
+
+func (a *markupAttrs) str(name string) string {
+	return a.e.Attrs[name]
+}
+
+func (a *markupAttrs) int(name string) int {
+	v, ok := a.e.Attrs[name]
+	if !ok {
+		return 0
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		a.fail(name, v)
+	}
+	return i
+}
+
+func (a *markupAttrs) float(name string) float64 {
+	v, ok := a.e.Attrs[name]
+	if !ok {
+		return 0
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		a.fail(name, v)
+	}
+	return f
+}
+
+func (a *markupAttrs) bool(name string) bool {
+	v, ok := a.e.Attrs[name]
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		a.fail(name, v)
+	}
+	return b
+}
+
+func (a *markupAttrs) color(name string) color.Color {
+	v, ok := a.e.Attrs[name]
+	if !ok {
+		return nil
+	}
+	c, err := ParseHexColor(v)
+	if err != nil {
+		a.fail(name, v)
+	}
+	return c
+}
+
+// quad parses one, two or four numbers in the order of CSS.
+func (a *markupAttrs) quad(name string) Quad {
+	v, ok := a.e.Attrs[name]
+	if !ok {
+		return Quad{}
+	}
+	fields := strings.Fields(v)
+	n := make([]int, len(fields))
+	for i := 0; i < len(fields); i++ {
+		var err error
+		if n[i], err = strconv.Atoi(fields[i]); err != nil {
+			a.fail(name, v)
+			return Quad{}
+		}
+	}
+	switch len(n) {
+	case 1:
+		return UniformQuad(n[0])
+	case 2:
+		return Quad{Top: n[0], Bottom: n[0], Left: n[1], Right: n[1]}
+	case 4:
+		return Quad{Top: n[0], Right: n[1], Bottom: n[2], Left: n[3]}
+	}
+	a.fail(name, v)
+	return Quad{}
+}
+
+func (a *markupAttrs) textStyle(name string) TextStyle {
+	switch v := a.e.Attrs[name]; v {
+	case "", "body":
+		return TextBody
+	case "caption":
+		return TextCaption
+	case "title":
+		return TextTitle
+	case "heading":
+		return TextHeading
+	default:
+		a.fail(name, v)
+	}
+	return TextBody
+}
+
+func (a *markupAttrs) justify(name string) Justify {
+	switch v := a.e.Attrs[name]; v {
+	case "", "start":
+		return JustifyStart
+	case "end":
+		return JustifyEnd
+	case "center":
+		return JustifyCenter
+	case "space-between":
+		return JustifySpaceBetween
+	case "space-around":
+		return JustifySpaceAround
+	case "space-evenly":
+		return JustifySpaceEvenly
+	default:
+		a.fail(name, v)
+	}
+	return JustifyStart
+}
+
+func (a *markupAttrs) align(name string) Align {
+	switch v := a.e.Attrs[name]; v {
+	case "", "auto":
+		return AlignAuto
+	case "start":
+		return AlignStart
+	case "end":
+		return AlignEnd
+	case "center":
+		return AlignCenter
+	case "stretch":
+		return AlignStretch
+	default:
+		a.fail(name, v)
+	}
+	return AlignAuto
+}
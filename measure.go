@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"image"
+)
+
+// Constraints is the space available to a component when it is measured. A zero dimension is unbounded.
+type Constraints struct {
+	MaxWidth, MaxHeight int
+}
+
+// SizeHints stores the minimum, preferred and maximum sizes of a component. A zero maximum dimension is unbounded.
+// Origin is the top left corner the component draws at in its own coordinates, so a layout can move the component to
+// the rectangle it assigns without offsetting it twice.
+type SizeHints struct {
+	Min, Preferred, Max image.Point
+	Origin              image.Point
+}
+
+// Measurer is implemented by components which can report their intrinsic size.
+type Measurer interface {
+	Measure(c Constraints) SizeHints
+}
+
+// FixedSizeHints returns size hints for a component which can only be the given size.
+func FixedSizeHints(w, h int) SizeHints {
+	size := image.Pt(w, h)
+	return SizeHints{Min: size, Preferred: size, Max: size}
+}
+
+// BoundsSizeHints returns size hints for a component which can only be the size of the rectangle and draws at its
+// position.
+func BoundsSizeHints(r image.Rectangle) SizeHints {
+	hints := FixedSizeHints(r.Dx(), r.Dy())
+	hints.Origin = r.Min
+	return hints
+}
+
+// Measure returns the size hints for a component. Components which do not implement Measurer have no size.
+func Measure(c Component, cons Constraints) SizeHints {
+	if m, ok := c.(Measurer); ok {
+		return m.Measure(cons)
+	}
+	return SizeHints{}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MenuItem is an item of a menu. Disabled items are drawn muted and cannot be chosen.
+type MenuItem struct {
+	Label    string
+	Disabled bool
+
+	// OnSelect is called when the item is chosen.
+	OnSelect func()
+}
+
+// MenuOptions contains the options for a menu. Unset fonts and colors follow the theme. The menu is as wide as its
+// widest item or Width, whichever is larger, and each item is ItemHeight pixels tall, which defaults to the line height
+// of the font with the spacing of the theme.
+type MenuOptions struct {
+	Font       string
+	FontSize   float64
+	TextColor  color.Color
+	Width      int
+	ItemHeight int
+}
+
+// Menu creates a themed menu of the items, such as a context menu. The item under the mouse is highlighted and
+// releasing the mouse over an item chooses it. Menus are usually shown with OverlayManager.ShowMenu, which closes the
+// menu once an item is chosen and resizes it whenever the theme changes its size.
+func Menu(items []MenuItem, opts *MenuOptions) *MenuComponent {
+	m := &MenuComponent{
+		opts:  opts,
+		items: items,
+		texts: make([]*DynamicTextComponent, len(items)),
+		hover: -1,
+	}
+	for i := 0; i < len(items); i++ {
+		m.texts[i] = DynamicText(&TextOptions{Font: opts.Font, FontSize: opts.FontSize, TextColor: opts.TextColor})
+		m.texts[i].SetText(items[i].Label)
+	}
+	return m
+}
+
+// MenuComponent is a vertical list of items.
+type MenuComponent struct {
+	opts       *MenuOptions
+	items      []MenuItem
+	texts      []*DynamicTextComponent
+	width      int
+	itemHeight int
+	hover      int
+	popup      *Popup
+}
+
+// HandleMouseEvent highlights the item under the mouse and chooses the item the mouse is released over. All events are
+// consumed so the components below the menu are not affected.
+func (m *MenuComponent) HandleMouseEvent(evt *PointerEvent) bool {
+	index := m.indexAt(evt.LocalX, evt.LocalY)
+	switch evt.EventType {
+	case MouseMoveEvent, MouseEnterEvent:
+		m.hover = index
+	case MouseLeaveEvent:
+		m.hover = -1
+	case MouseReleaseEvent:
+		if index >= 0 && !m.items[index].Disabled {
+			m.Choose(index)
+		}
+	}
+	evt.PreventDefault()
+	return true
+}
+
+// Choose closes the menu and calls OnSelect of the item.
+func (m *MenuComponent) Choose(index int) {
+	if m.popup != nil {
+		m.popup.Close()
+	}
+	if m.items[index].OnSelect != nil {
+		m.items[index].OnSelect()
+	}
+}
+
+// Contains returns true if the point is within the menu.
+func (m *MenuComponent) Contains(x, y int) bool {
+	return image.Pt(x, y).In(image.Rect(0, 0, m.width, len(m.items)*m.itemHeight))
+}
+
+// Update updates the item colors and sizes the menu from the theme.
+func (m *MenuComponent) Update(ctx *UpdateContext) error {
+	theme := ThemeFromContext(ctx.Context())
+	for i := 0; i < len(m.texts); i++ {
+		if m.items[i].Disabled {
+			m.texts[i].SetTextColor(theme.Widget.Disabled.Foreground)
+		} else {
+			m.texts[i].SetTextColor(m.opts.TextColor)
+		}
+		if err := m.texts[i].Update(ctx); err != nil {
+			return err
+		}
+	}
+	m.resize(theme)
+	return nil
+}
+
+// resize sizes the menu to its widest item with the spacing of the theme. The popup of the menu is resized with it.
+func (m *MenuComponent) resize(t *Theme) {
+	width := m.opts.Width
+	for i := 0; i < len(m.texts); i++ {
+		if w := Measure(m.texts[i], Constraints{}).Preferred.X + 2*t.Spacing.M; w > width {
+			width = w
+		}
+	}
+	height := m.opts.ItemHeight
+	if height <= 0 && len(m.texts) > 0 {
+		metrics := m.texts[0].FontFace().Metrics()
+		height = metrics.Ascent.Ceil() + metrics.Descent.Ceil() + 2*t.Spacing.S
+	}
+
+	if width == m.width && height == m.itemHeight {
+		return
+	}
+	m.width, m.itemHeight = width, height
+	if m.popup != nil {
+		m.popup.SetSize(image.Pt(m.width, len(m.items)*m.itemHeight))
+	}
+}
+
+// Display renders the items and highlights the item under the mouse.
+func (m *MenuComponent) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	drawBox(ctx, Rect(0, 0, m.width, len(m.items)*m.itemHeight), theme.Palette.Surface, theme.Palette.Border,
+		theme.BorderWidth)
+
+	for i := 0; i < len(m.items); i++ {
+		row := Rect(theme.BorderWidth, i*m.itemHeight, m.width-2*theme.BorderWidth, m.itemHeight)
+		if i == m.hover && !m.items[i].Disabled {
+			vs, is := RectVertices(row.Min.X, row.Min.Y, row.Max.X, row.Max.Y, theme.Palette.Selection)
+			ctx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+		}
+		drawTextAt(ctx, m.texts[i], row.Min.X+theme.Spacing.M, textBaseline(m.texts[i], row))
+	}
+}
+
+// Measure returns the size of the menu, which is empty until the menu is updated.
+func (m *MenuComponent) Measure(c Constraints) SizeHints {
+	return FixedSizeHints(m.width, len(m.items)*m.itemHeight)
+}
+
+// indexAt returns the index of the item at the point or -1 if there is none.
+func (m *MenuComponent) indexAt(x, y int) int {
+	if !m.Contains(x, y) {
+		return -1
+	}
+	return y / m.itemHeight
+}
@@ -5,7 +5,6 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 // MouseEventType enumerates the type of mouse event.
@@ -18,6 +17,24 @@ func (evt MouseEventType) String() string {
 		return "MousePressed"
 	case MouseReleaseEvent:
 		return "MouseReleased"
+	case MouseMoveEvent:
+		return "MouseMoved"
+	case MouseWheelEvent:
+		return "MouseWheel"
+	case MouseDoubleClickEvent:
+		return "MouseDoubleClick"
+	case MouseTripleClickEvent:
+		return "MouseTripleClick"
+	case MouseDragStartEvent:
+		return "MouseDragStart"
+	case MouseDragEvent:
+		return "MouseDrag"
+	case MouseDropEvent:
+		return "MouseDrop"
+	case MouseEnterEvent:
+		return "MouseEnter"
+	case MouseLeaveEvent:
+		return "MouseLeave"
 	}
 	return "Unknown"
 }
@@ -32,16 +49,67 @@ const (
 
 	// MouseMoveEvent occurs when the mouse moves.
 	MouseMoveEvent
+
+	// MouseWheelEvent occurs when the mouse wheel scrolls. WheelX and WheelY hold the offset.
+	MouseWheelEvent
+
+	// MouseDoubleClickEvent occurs after the second press of a button in quick succession.
+	MouseDoubleClickEvent
+
+	// MouseTripleClickEvent occurs after the third press of a button in quick succession.
+	MouseTripleClickEvent
+
+	// MouseDragStartEvent occurs when the mouse moves past the drag threshold while a button is held.
+	MouseDragStartEvent
+
+	// MouseDragEvent occurs when the mouse moves during a drag. DeltaX and DeltaY hold the movement.
+	MouseDragEvent
+
+	// MouseDropEvent occurs when the button is released at the end of a drag.
+	MouseDropEvent
+
+	// MouseEnterEvent occurs when the mouse enters the region of a handler.
+	MouseEnterEvent
+
+	// MouseLeaveEvent occurs when the mouse leaves the region of a handler.
+	MouseLeaveEvent
 )
 
-// MouseEvent stores the mouse butten and event type.
+// These are the default click and drag settings.
+const (
+
+	// DefaultClickInterval is the maximum number of ticks between the presses of a double or triple click.
+	DefaultClickInterval = 20
+
+	// DefaultClickDistance is the maximum distance in pixels between the presses of a double or triple click.
+	DefaultClickDistance = 4
+
+	// DefaultDragThreshold is the distance in pixels the mouse must move while pressed to start a drag.
+	DefaultDragThreshold = 4
+)
+
+// MouseButtonNone is the button of mouse events which are not caused by a button, such as wheel, move, enter and leave
+// events. The zero button is the left button.
+const MouseButtonNone ebiten.MouseButton = -1
+
+// mouseButtons are the buttons reported by ebiten. Ebiten v2.0.2 only defines the left, middle and right buttons, so the
+// back and forward side buttons cannot be tracked until ebiten is upgraded.
+var mouseButtons = []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonMiddle, ebiten.MouseButtonRight}
+
+// MouseEvent stores the mouse butten and event type. Clicks is the number of presses in quick succession for press
+// and click events. Only the left, middle and right buttons are supported; the back and forward side buttons are not
+// reported by the version of ebiten in use. Events which are not caused by a button have MouseButtonNone.
 type MouseEvent struct {
 	Button    ebiten.MouseButton
 	EventType MouseEventType
+
+	Clicks         int
+	WheelX, WheelY float64
+	DeltaX, DeltaY int
 }
 
 func (evt MouseEvent) String() string {
-	return fmt.Sprintf("MouseButtonEvent: Button=%d Event=%s", evt.Button, evt.EventType.String())
+	return fmt.Sprintf("MouseButtonEvent: Button=%d Event=%s Clicks=%d", evt.Button, evt.EventType.String(), evt.Clicks)
 }
 
 // MouseButtonHandler is dispatched whenever mouse button events occur.
@@ -54,7 +122,8 @@ type MouseMoveHandler interface {
 	OnMouseMove(x, y int)
 }
 
-// DefaultMouseEventRegistry is the root mouse event registry.
+// DefaultMouseEventRegistry is a mouse event registry for handlers outside of a display. Every display has its own
+// registry.
 var DefaultMouseEventRegistry = NewMouseEventRegistry(0, 0)
 
 // NewMouseEventRegistry creates a new mouse event registry with the given origin.
@@ -64,9 +133,26 @@ func NewMouseEventRegistry(x, y int) *MouseEventRegistry {
 		make([]MouseMoveHandler, 0),
 		image.Pt(x, y),
 		image.Pt(0, 0),
+		DefaultClickInterval,
+		DefaultClickDistance,
+		DefaultDragThreshold,
+		0,
+		make(map[ebiten.MouseButton]*buttonState),
+		make(map[Hittable]bool),
+		EbitenInput,
 	}
 }
 
+// buttonState tracks the clicks and drag of a mouse button.
+type buttonState struct {
+	pressed   image.Point
+	last      image.Point
+	pressTick int
+	clicks    int
+	down      bool
+	dragging  bool
+}
+
 // MouseEventRegistry stores all the mouse button handlers.
 type MouseEventRegistry struct {
 	handlers     []MouseButtonHandler
@@ -74,6 +160,30 @@ type MouseEventRegistry struct {
 	origin       image.Point
 
 	lastMousePosition image.Point
+
+	clickInterval int
+	clickDistance int
+	dragThreshold int
+	tick          int
+	buttons       map[ebiten.MouseButton]*buttonState
+	hovered       map[Hittable]bool
+	input         InputSource
+}
+
+// SetInput sets the source of the mouse input.
+func (r *MouseEventRegistry) SetInput(input InputSource) {
+	r.input = input
+}
+
+// SetClickTiming sets the maximum ticks and distance between the presses of a double or triple click.
+func (r *MouseEventRegistry) SetClickTiming(interval, distance int) {
+	r.clickInterval = interval
+	r.clickDistance = distance
+}
+
+// SetDragThreshold sets the distance the mouse must move while pressed to start a drag.
+func (r *MouseEventRegistry) SetDragThreshold(px int) {
+	r.dragThreshold = px
 }
 
 // AddButtonHandler adds a button handler to the registry.
@@ -88,44 +198,115 @@ func (r *MouseEventRegistry) AddMoveHandler(h MouseMoveHandler) {
 
 // Update gets the latest mouse events and dispatches them to the handlers
 func (r *MouseEventRegistry) Update() {
-	mouseX, mouseY := ebiten.CursorPosition()
+	r.tick++
+	mouseX, mouseY := r.input.CursorPosition()
+	pos := image.Pt(mouseX, mouseY)
 
-	// left
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		r.Dispatch(mouseX, mouseY, MouseEvent{Button: ebiten.MouseButtonLeft, EventType: MousePressEvent})
-	}
-	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
-		r.Dispatch(mouseX, mouseY, MouseEvent{Button: ebiten.MouseButtonLeft, EventType: MouseReleaseEvent})
-	}
+	for _, button := range mouseButtons {
+		state, ok := r.buttons[button]
+		if !ok {
+			state = &buttonState{}
+			r.buttons[button] = state
+		}
 
-	// middle
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
-		r.Dispatch(mouseX, mouseY, MouseEvent{Button: ebiten.MouseButtonMiddle, EventType: MousePressEvent})
-	}
-	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonMiddle) {
-		r.Dispatch(mouseX, mouseY, MouseEvent{Button: ebiten.MouseButtonMiddle, EventType: MouseReleaseEvent})
-	}
+		if r.input.IsMouseButtonJustPressed(button) {
+			// count presses in quick succession
+			if state.clicks > 0 && r.tick-state.pressTick <= r.clickInterval && distance(pos, state.pressed) <= r.clickDistance {
+				state.clicks++
+			} else {
+				state.clicks = 1
+			}
+			state.pressTick = r.tick
+			state.pressed, state.last = pos, pos
+			state.down, state.dragging = true, false
 
-	// right
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		r.Dispatch(mouseX, mouseY, MouseEvent{Button: ebiten.MouseButtonRight, EventType: MousePressEvent})
+			r.Dispatch(mouseX, mouseY, MouseEvent{Button: button, EventType: MousePressEvent, Clicks: state.clicks})
+			switch state.clicks {
+			case 2:
+				r.Dispatch(mouseX, mouseY, MouseEvent{Button: button, EventType: MouseDoubleClickEvent, Clicks: 2})
+			case 3:
+				r.Dispatch(mouseX, mouseY, MouseEvent{Button: button, EventType: MouseTripleClickEvent, Clicks: 3})
+			}
+		}
+
+		// drag while the button is held
+		if state.down && pos != state.last {
+			if !state.dragging && distance(pos, state.pressed) >= r.dragThreshold {
+				state.dragging = true
+				delta := pos.Sub(state.pressed)
+				r.Dispatch(state.pressed.X, state.pressed.Y, MouseEvent{Button: button, EventType: MouseDragStartEvent, DeltaX: delta.X, DeltaY: delta.Y})
+			} else if state.dragging {
+				delta := pos.Sub(state.last)
+				r.Dispatch(mouseX, mouseY, MouseEvent{Button: button, EventType: MouseDragEvent, DeltaX: delta.X, DeltaY: delta.Y})
+			}
+			if state.dragging {
+				state.last = pos
+			}
+		}
+
+		if r.input.IsMouseButtonJustReleased(button) {
+			if state.dragging {
+				delta := pos.Sub(state.pressed)
+				r.Dispatch(mouseX, mouseY, MouseEvent{Button: button, EventType: MouseDropEvent, DeltaX: delta.X, DeltaY: delta.Y})
+			}
+			state.down, state.dragging = false, false
+			r.Dispatch(mouseX, mouseY, MouseEvent{Button: button, EventType: MouseReleaseEvent, Clicks: state.clicks})
+		}
 	}
-	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight) {
-		r.Dispatch(mouseX, mouseY, MouseEvent{Button: ebiten.MouseButtonRight, EventType: MouseReleaseEvent})
+
+	// wheel
+	if wx, wy := r.input.Wheel(); wx != 0 || wy != 0 {
+		r.Dispatch(mouseX, mouseY, MouseEvent{Button: MouseButtonNone, EventType: MouseWheelEvent, WheelX: wx, WheelY: wy})
 	}
 
 	// dispatch move handlers
 	ex, ey := mouseX-r.origin.X, mouseY-r.origin.Y
-	for i := 0; i < len(r.moveHandlers); i++ {
-		if mouseX != r.lastMousePosition.X || mouseY != r.lastMousePosition.Y {
+	if mouseX != r.lastMousePosition.X || mouseY != r.lastMousePosition.Y {
+		for i := 0; i < len(r.moveHandlers); i++ {
 			r.moveHandlers[i].OnMouseMove(ex, ey)
-			// fmt.Printf("calling mouse move %d %d\n", ex, ey)
 		}
+		r.updateHover(ex, ey)
+	}
+	r.lastMousePosition = pos
+}
+
+// updateHover emits enter and leave events to the button handlers which implement Hittable.
+func (r *MouseEventRegistry) updateHover(x, y int) {
+	for i := 0; i < len(r.handlers); i++ {
+		h, ok := r.handlers[i].(Hittable)
+		if !ok {
+			continue
+		}
+		inside := h.Contains(x, y)
+		if inside == r.hovered[h] {
+			continue
+		}
+		r.hovered[h] = inside
+		if inside {
+			r.handlers[i].OnMouseEvent(x, y, MouseEvent{Button: MouseButtonNone, EventType: MouseEnterEvent})
+		} else {
+			r.handlers[i].OnMouseEvent(x, y, MouseEvent{Button: MouseButtonNone, EventType: MouseLeaveEvent})
+		}
+	}
+}
+
+// distance returns the larger of the horizontal and vertical distances between the points.
+func distance(a, b image.Point) int {
+	d := a.Sub(b)
+	if d.X < 0 {
+		d.X = -d.X
+	}
+	if d.Y < 0 {
+		d.Y = -d.Y
+	}
+	if d.X > d.Y {
+		return d.X
 	}
-	r.lastMousePosition = image.Pt(mouseX, mouseY)
+	return d.Y
 }
 
-// Dispatch emits a handler event to the handlers when fired.
+// Dispatch emits a handler event to the handlers when fired. Every handler receives every event; add components to a
+// Display to have events propagate through the scene graph instead.
 func (r *MouseEventRegistry) Dispatch(x, y int, evt MouseEvent) {
 	ex, ey := x-r.origin.X, y-r.origin.Y
 	for i := 0; i < len(r.handlers); i++ {
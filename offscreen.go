@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"errors"
+	"image"
+	"log"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ErrNotOffscreen is returned by Render when the off screen game loop is not running.
+var ErrNotOffscreen = errors.New("off screen game loop is not running")
+
+// errOffscreenDone stops the off screen game loop.
+var errOffscreenDone = errors.New("off screen done")
+
+var (
+	offscreenRunning int32
+	offscreenJobs    = make(chan renderJob)
+)
+
+// renderJob is a request to render a display off screen.
+type renderJob struct {
+	display *Display
+	frames  int
+	result  chan renderResult
+}
+
+type renderResult struct {
+	img *image.RGBA
+	err error
+}
+
+// RunOffscreen runs the function while an off screen game loop renders the displays passed to Render and returns the
+// result of the function. It must be called from the main goroutine, for example from TestMain.
+//
+// Rendering is off screen but not headless. Ebiten draws with OpenGL through GLFW, so the loop opens a small window
+// which is never drawn to and a window system is required. On Linux without a GPU run under Xvfb (xvfb-run), where
+// Mesa rasterizes in software.
+func RunOffscreen(fn func() int) int {
+	game := &offscreenGame{done: make(chan struct{})}
+
+	var code int
+	go func() {
+		defer close(game.done)
+		code = fn()
+	}()
+
+	atomic.StoreInt32(&offscreenRunning, 1)
+	defer atomic.StoreInt32(&offscreenRunning, 0)
+
+	ebiten.SetWindowSize(16, 16)
+	ebiten.SetWindowTitle("offscreen")
+	ebiten.SetRunnableOnUnfocused(true)
+	if err := ebiten.RunGame(game); err != nil && err != errOffscreenDone {
+		log.Fatalf("off screen game loop failed: %s", err)
+	}
+	return code
+}
+
+// Render runs the display for the number of frames off screen and returns the last frame. Each frame updates the
+// display once and draws it once. Render must be called while RunOffscreen is running.
+func (d *Display) Render(frames int) (*image.RGBA, error) {
+	if atomic.LoadInt32(&offscreenRunning) == 0 {
+		return nil, ErrNotOffscreen
+	}
+	result := make(chan renderResult, 1)
+	offscreenJobs <- renderJob{d, frames, result}
+	r := <-result
+	return r.img, r.err
+}
+
+// render updates and draws the display into an off screen image. It must be called from the game loop.
+func (d *Display) render(frames int) (*image.RGBA, error) {
+	if frames < 1 {
+		frames = 1
+	}
+	w, h := d.settings.Width, d.settings.Height
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	screen := ebiten.NewImage(w, h)
+	defer screen.Dispose()
+
+	for i := 0; i < frames; i++ {
+		if err := d.Update(); err != nil {
+			return nil, err
+		}
+		screen.Clear()
+		d.Draw(screen)
+	}
+
+	return readPixels(screen), nil
+}
+
+// offscreenGame is the game loop which renders displays off screen.
+type offscreenGame struct {
+	done chan struct{}
+}
+
+// Update renders the pending displays and stops the loop once the function has returned.
+func (g *offscreenGame) Update() error {
+	for {
+		select {
+		case job := <-offscreenJobs:
+			img, err := job.display.render(job.frames)
+			job.result <- renderResult{img, err}
+		case <-g.done:
+			return errOffscreenDone
+		default:
+			return nil
+		}
+	}
+}
+
+// Draw is a no-op.
+func (g *offscreenGame) Draw(screen *ebiten.Image) {}
+
+// Layout returns a minimal screen size.
+func (g *offscreenGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return 16, 16
+}
@@ -0,0 +1,107 @@
+package ui_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/eliquious/ui"
+	"github.com/eliquious/ui/uitest"
+)
+
+func TestMain(m *testing.M) {
+	uitest.Main(m)
+}
+
+var (
+	white = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	black = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	blue  = color.RGBA{0x33, 0x99, 0xff, 0xff}
+)
+
+// assertPixel checks the color of the pixel within a tolerance for antialiasing.
+func assertPixel(t *testing.T, img *image.RGBA, x, y int, expected color.RGBA) {
+	t.Helper()
+	actual := img.RGBAAt(x, y)
+	within := func(a, b uint8) bool {
+		d := int(a) - int(b)
+		return d >= -2 && d <= 2
+	}
+	if !within(actual.R, expected.R) || !within(actual.G, expected.G) || !within(actual.B, expected.B) ||
+		!within(actual.A, expected.A) {
+		t.Errorf("pixel %d,%d: expected %v; got %v", x, y, expected, actual)
+	}
+}
+
+func TestRenderRectangle(t *testing.T) {
+	img, err := uitest.Render(ui.Rectangle(ui.Rect(16, 16, 96, 64), &ui.RectangleOptions{
+		FillColor: blue,
+		Border:    ui.StrokeBorder(color.Black, 4),
+	}), &uitest.Options{Width: 128, Height: 96, Background: color.White})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPixel(t, img, 4, 4, white)
+	assertPixel(t, img, 18, 48, black)
+	assertPixel(t, img, 64, 48, blue)
+}
+
+func TestRenderCircle(t *testing.T) {
+	img, err := uitest.Render(ui.Circle(64, 64, 40, &ui.CircleOptions{
+		FillColor: blue,
+		Stroke:    ui.Stroke{Color: color.Black, Width: 4},
+	}), &uitest.Options{Width: 128, Height: 128, Background: color.White})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPixel(t, img, 4, 4, white)
+	assertPixel(t, img, 64, 64, blue)
+}
+
+func TestRenderText(t *testing.T) {
+	img, err := uitest.Render(ui.Text("Render", 8, 8, &ui.TextOptions{
+		FontSize:        24,
+		TextColor:       color.Black,
+		BackgroundColor: color.White,
+		Padding:         ui.UniformQuad(4),
+	}), &uitest.Options{Width: 128, Height: 48, Background: color.RGBA{0xff, 0x00, 0x00, 0xff}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the background is drawn around the text within the padding and the glyphs are dark
+	assertPixel(t, img, 2, 2, color.RGBA{0xff, 0x00, 0x00, 0xff})
+	assertPixel(t, img, 5, 5, white)
+	dark := 0
+	for y := 4; y < 48; y++ {
+		for x := 4; x < 128; x++ {
+			if c := img.RGBAAt(x, y); c.R < 0x40 && c.G < 0x40 && c.B < 0x40 {
+				dark++
+			}
+		}
+	}
+	if dark == 0 {
+		t.Error("expected the glyphs to be drawn")
+	}
+}
+
+func TestRenderContainer(t *testing.T) {
+	img, err := uitest.Render(ui.Container(ui.Rect(8, 8, 112, 112), &ui.ContainerOptions{
+		Margin:  ui.UniformQuad(4),
+		Border:  ui.StrokeBorder(color.Black, 2),
+		Padding: ui.UniformQuad(8),
+	},
+		ui.Rectangle(ui.Rect(0, 0, 48, 48), &ui.RectangleOptions{FillColor: blue}),
+	), &uitest.Options{Width: 128, Height: 128, Background: color.White})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the child is drawn within the margin, border and padding, which start at 8+4+2+8
+	assertPixel(t, img, 4, 4, white)
+	assertPixel(t, img, 13, 64, black)
+	assertPixel(t, img, 20, 24, white)
+	assertPixel(t, img, 24, 24, blue)
+	assertPixel(t, img, 68, 68, blue)
+	assertPixel(t, img, 72, 72, white)
+}
@@ -0,0 +1,414 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// These are the names of the overlay layers created for every display, from the bottom up.
+const (
+	PopupLayer   = "popup"
+	ModalLayer   = "modal"
+	MenuLayer    = "menu"
+	TooltipLayer = "tooltip"
+)
+
+// DefaultScrimColor dims the screen behind modal dialogs.
+var DefaultScrimColor = color.NRGBA{0, 0, 0, 0x80}
+
+// Placement is the side of the anchor a popup is placed on.
+type Placement int
+
+// These are the available placements.
+const (
+
+	// PlaceBelow places the popup below the anchor, aligned with its left edge.
+	PlaceBelow Placement = iota
+
+	// PlaceAbove places the popup above the anchor, aligned with its left edge.
+	PlaceAbove
+
+	// PlaceRight places the popup right of the anchor, aligned with its top edge.
+	PlaceRight
+
+	// PlaceLeft places the popup left of the anchor, aligned with its top edge.
+	PlaceLeft
+
+	// PlaceCenter centers the popup on the anchor.
+	PlaceCenter
+)
+
+// Place returns the rectangle of a popup of the size placed next to the anchor, separated by the offset. A popup which
+// would leave the screen on the chosen side is flipped to the opposite side if it fits there, and is then moved to
+// stay within the screen.
+func Place(anchor image.Rectangle, size image.Point, screen image.Rectangle, placement Placement, offset int) image.Rectangle {
+	var pt image.Point
+	switch placement {
+	case PlaceBelow, PlaceAbove:
+		below := anchor.Max.Y + offset
+		above := anchor.Min.Y - offset - size.Y
+		pt = image.Pt(anchor.Min.X, below)
+		if placement == PlaceAbove {
+			pt.Y = above
+		}
+		if pt.Y == below && below+size.Y > screen.Max.Y && above >= screen.Min.Y {
+			pt.Y = above
+		} else if pt.Y == above && above < screen.Min.Y && below+size.Y <= screen.Max.Y {
+			pt.Y = below
+		}
+	case PlaceRight, PlaceLeft:
+		right := anchor.Max.X + offset
+		left := anchor.Min.X - offset - size.X
+		pt = image.Pt(right, anchor.Min.Y)
+		if placement == PlaceLeft {
+			pt.X = left
+		}
+		if pt.X == right && right+size.X > screen.Max.X && left >= screen.Min.X {
+			pt.X = left
+		} else if pt.X == left && left < screen.Min.X && right+size.X <= screen.Max.X {
+			pt.X = right
+		}
+	default:
+		pt = image.Pt(anchor.Min.X+(anchor.Dx()-size.X)/2, anchor.Min.Y+(anchor.Dy()-size.Y)/2)
+	}
+
+	// keep the popup on the screen, preferring the top left corner when it is larger than the screen
+	if pt.X+size.X > screen.Max.X {
+		pt.X = screen.Max.X - size.X
+	}
+	if pt.X < screen.Min.X {
+		pt.X = screen.Min.X
+	}
+	if pt.Y+size.Y > screen.Max.Y {
+		pt.Y = screen.Max.Y - size.Y
+	}
+	if pt.Y < screen.Min.Y {
+		pt.Y = screen.Min.Y
+	}
+	return image.Rectangle{pt, pt.Add(size)}
+}
+
+// NewOverlayManager creates an overlay manager with the popup, modal, menu and tooltip layers as children of the root
+// node. The components of popups are added to the focus manager while they are open.
+func NewOverlayManager(root *Node, focus *FocusManager) *OverlayManager {
+	m := &OverlayManager{root: root, focus: focus, layers: make(map[string]*Node)}
+	m.AddLayer(PopupLayer, 100)
+	m.AddLayer(ModalLayer, 200)
+	m.AddLayer(MenuLayer, 300)
+	m.AddLayer(TooltipLayer, 400)
+	return m
+}
+
+// OverlayManager manages a stack of named layers above the components of a display, where popups, modal dialogs,
+// menus and tooltips are shown. Popups are placed next to an anchor rectangle in screen coordinates, such as the
+// ScreenBounds of a node, and are kept within the screen.
+type OverlayManager struct {
+	root   *Node
+	focus  *FocusManager
+	layers map[string]*Node
+	popups []*Popup
+	screen image.Rectangle
+}
+
+// Root returns the node which contains the layers.
+func (m *OverlayManager) Root() *Node {
+	return m.root
+}
+
+// AddLayer adds a named layer. Layers with a higher z-index are drawn above and hit first. An existing layer with the
+// same name is moved to the z-index.
+func (m *OverlayManager) AddLayer(name string, z int) *Node {
+	if l, ok := m.layers[name]; ok {
+		return l.SetZIndex(z)
+	}
+	l := NewNode(nil).SetZIndex(z)
+	m.layers[name] = l
+	m.root.Add(l)
+	return l
+}
+
+// Layer returns the named layer or nil if there is no such layer.
+func (m *OverlayManager) Layer(name string) *Node {
+	return m.layers[name]
+}
+
+// SetScreenSize sets the size of the screen which popups are kept within and moves the open popups.
+func (m *OverlayManager) SetScreenSize(width, height int) {
+	screen := image.Rect(0, 0, width, height)
+	if screen == m.screen {
+		return
+	}
+	m.screen = screen
+	for i := 0; i < len(m.popups); i++ {
+		m.popups[i].place()
+	}
+}
+
+// Screen returns the screen rectangle.
+func (m *OverlayManager) Screen() image.Rectangle {
+	return m.screen
+}
+
+// PopupOptions contains the options for a popup. The popup is shown in the Layer, which defaults to the popup layer,
+// and placed on the side of the Anchor given by Placement, Offset pixels away. The size of the popup is measured from
+// the component unless Size is set.
+//
+// A popup which is dismissed by clicking outside of it consumes that click. A modal popup dims the screen behind it
+// with the ScrimColor, which defaults to DefaultScrimColor, blocks the mouse from reaching the components below and
+// limits keyboard focus to its own components.
+type PopupOptions struct {
+	Layer      string
+	Anchor     image.Rectangle
+	Placement  Placement
+	Offset     int
+	Size       image.Point
+	Dismiss    bool
+	Modal      bool
+	ScrimColor color.Color
+
+	// OnClose is called when the popup is closed.
+	OnClose func()
+}
+
+// Show shows the component in an overlay layer. Focusable components within the popup receive keyboard input while
+// it is open. Popups are hit by the mouse within their size, except in the tooltip layer, which lets the mouse through.
+func (m *OverlayManager) Show(c Component, opts *PopupOptions) *Popup {
+	name := opts.Layer
+	if name == "" {
+		name = PopupLayer
+	}
+	layer := m.layers[name]
+	if layer == nil {
+		layer = m.AddLayer(name, 0)
+	}
+
+	n, ok := c.(*Node)
+	if !ok {
+		n = NewNode(c)
+	}
+	p := &Popup{manager: m, opts: opts, layer: layer, node: n, hit: name != TooltipLayer, open: true}
+	if opts.Dismiss || opts.Modal {
+		p.scrim = NewNode(&scrim{p}).SetBounds(image.Rect(-1<<20, -1<<20, 1<<20, 1<<20))
+		layer.Add(p.scrim)
+	}
+	layer.Add(n)
+
+	size := opts.Size
+	if size == (image.Point{}) {
+		size = Measure(c, Constraints{m.screen.Dx(), m.screen.Dy()}).Preferred
+	}
+	p.SetSize(size)
+
+	// the focusable components of the popup
+	n.Walk(func(n *Node) {
+		if f, ok := n.component.(Focusable); ok {
+			p.focusables = append(p.focusables, f)
+		}
+	})
+	if opts.Modal {
+		p.scope = m.focus.PushScope(p.focusables...)
+		m.focus.Next()
+	} else {
+		m.focus.Add(p.focusables...)
+	}
+
+	m.popups = append(m.popups, p)
+	return p
+}
+
+// ShowModal shows the component as a modal dialog in the center of the screen. While it is open, keyboard input only
+// reaches the focused component of the dialog and not the key handlers of the display.
+func (m *OverlayManager) ShowModal(c Component, opts *PopupOptions) *Popup {
+	o := *opts
+	o.Modal = true
+	o.Placement = PlaceCenter
+	if o.Layer == "" {
+		o.Layer = ModalLayer
+	}
+	return m.Show(c, &o)
+}
+
+// ShowTooltip shows the component below the anchor in the tooltip layer. The tooltip is not hit by the mouse.
+func (m *OverlayManager) ShowTooltip(c Component, anchor image.Rectangle) *Popup {
+	return m.Show(c, &PopupOptions{Layer: TooltipLayer, Anchor: anchor, Offset: 4})
+}
+
+// ShowMenu shows a menu of the items at the screen point in the menu layer. The menu closes when an item is chosen or
+// the mouse is pressed outside of it.
+func (m *OverlayManager) ShowMenu(x, y int, items []MenuItem, opts *MenuOptions) *Popup {
+	menu := Menu(items, opts)
+	p := m.Show(menu, &PopupOptions{Layer: MenuLayer, Anchor: image.Rect(x, y, x, y), Dismiss: true})
+	menu.popup = p
+	return p
+}
+
+// Close closes all the popups in the layer.
+func (m *OverlayManager) Close(layer string) {
+	popups := append([]*Popup(nil), m.popups...)
+	for i := 0; i < len(popups); i++ {
+		if popups[i].layer == m.layers[layer] {
+			popups[i].Close()
+		}
+	}
+}
+
+// CloseAll closes every popup.
+func (m *OverlayManager) CloseAll() {
+	for len(m.popups) > 0 {
+		m.popups[len(m.popups)-1].Close()
+	}
+}
+
+// Top returns the popup which is drawn on top and takes mouse input, which is the last shown in the highest layer, or nil
+// if there is none. Tooltips are skipped.
+func (m *OverlayManager) Top() *Popup {
+	var top *Popup
+	for i := 0; i < len(m.popups); i++ {
+		p := m.popups[i]
+		if p.hit && (top == nil || p.layer.ZIndex() >= top.layer.ZIndex()) {
+			top = p
+		}
+	}
+	return top
+}
+
+// Popups returns the open popups from the first shown to the last.
+func (m *OverlayManager) Popups() []*Popup {
+	return m.popups
+}
+
+// Popup is a component shown in an overlay layer.
+type Popup struct {
+	manager    *OverlayManager
+	opts       *PopupOptions
+	layer      *Node
+	node       *Node
+	scrim      *Node
+	focusables []Focusable
+	scope      *FocusScope
+	size       image.Point
+	anchor     *image.Rectangle
+	hit        bool
+	open       bool
+}
+
+// Node returns the node of the popup.
+func (p *Popup) Node() *Node {
+	return p.node
+}
+
+// Open returns true until the popup is closed.
+func (p *Popup) Open() bool {
+	return p.open
+}
+
+// Bounds returns the screen rectangle of the popup.
+func (p *Popup) Bounds() image.Rectangle {
+	x, y := p.node.ScreenPosition()
+	return image.Rectangle{image.Pt(int(x), int(y)), image.Pt(int(x), int(y)).Add(p.size)}
+}
+
+// SetAnchor moves the popup next to a new anchor.
+func (p *Popup) SetAnchor(anchor image.Rectangle) {
+	p.anchor = &anchor
+	p.place()
+}
+
+// SetSize resizes the popup and places it again.
+func (p *Popup) SetSize(size image.Point) {
+	if p.hit {
+		p.node.SetBounds(image.Rectangle{Max: size})
+	}
+	p.size = size
+	p.place()
+}
+
+// Close removes the popup from its layer and calls OnClose. Closing a closed popup does nothing.
+func (p *Popup) Close() {
+	if !p.open {
+		return
+	}
+	p.open = false
+
+	p.layer.Remove(p.node)
+	if p.scrim != nil {
+		p.layer.Remove(p.scrim)
+	}
+	for i := 0; i < len(p.focusables); i++ {
+		p.manager.focus.Remove(p.focusables[i])
+	}
+	if p.scope != nil {
+		p.manager.focus.RemoveScope(p.scope)
+	}
+
+	popups := p.manager.popups
+	for i := 0; i < len(popups); i++ {
+		if popups[i] == p {
+			p.manager.popups = append(popups[:i], popups[i+1:]...)
+			break
+		}
+	}
+	if p.opts.OnClose != nil {
+		p.opts.OnClose()
+	}
+}
+
+// place positions the popup next to its anchor within the screen.
+func (p *Popup) place() {
+	anchor := p.opts.Anchor
+	if p.anchor != nil {
+		anchor = *p.anchor
+	} else if p.opts.Modal {
+		anchor = p.manager.screen
+	}
+	r := Place(anchor, p.size, p.manager.screen, p.opts.Placement, p.opts.Offset)
+
+	// layers are children of the overlay root, which may itself be moved
+	x, y := p.layer.ScreenPosition()
+	p.node.SetPosition(float64(r.Min.X)-x, float64(r.Min.Y)-y)
+}
+
+// scrim covers the screen behind a popup. It dims the screen behind modal popups, consumes the mouse events which
+// would reach the components below and closes popups which are dismissed by clicking outside of them.
+type scrim struct {
+	popup *Popup
+}
+
+// HandleMouseEvent closes the popup on a press if it is dismissed by clicking outside of it.
+func (s *scrim) HandleMouseEvent(evt *PointerEvent) bool {
+	opts := s.popup.opts
+	if evt.EventType == MousePressEvent && opts.Dismiss {
+		s.popup.Close()
+	}
+
+	// keep the focus where it is
+	if opts.Modal {
+		evt.PreventDefault()
+		return true
+	}
+	return evt.EventType != MouseMoveEvent
+}
+
+// Update is a no-op.
+func (s *scrim) Update(ctx *UpdateContext) error {
+	return nil
+}
+
+// Display dims the screen behind modal popups.
+func (s *scrim) Display(ctx *DisplayContext) {
+	if !s.popup.opts.Modal {
+		return
+	}
+	c := s.popup.opts.ScrimColor
+	if c == nil {
+		c = DefaultScrimColor
+	}
+
+	// the scrim is drawn in screen coordinates
+	x, y := s.popup.layer.ScreenPosition()
+	r := s.popup.manager.screen.Sub(image.Pt(int(x), int(y)))
+	vs, is := RectVertices(r.Min.X, r.Min.Y, r.Max.X, r.Max.Y, c)
+	ctx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+}
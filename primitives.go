@@ -61,8 +61,21 @@ func Rect(x, y, w, h int) image.Rectangle {
 	return image.Rect(x, y, x+w, y+h)
 }
 
-// Rectangle draws a rectangle. All border lines are within the rectangle.
+// Rectangle draws a rectangle. All border lines are within the rectangle. Visible border sides without a color use
+// the border color of the theme.
 func Rectangle(r image.Rectangle, opts *RectangleOptions) Component {
+	if needsThemedBorder(opts.Border) {
+		return Themed(func(t *Theme) Component {
+			o := *opts
+			o.Border = themedBorder(o.Border, t)
+			return rectangle(r, &o)
+		})
+	}
+	return rectangle(r, opts)
+}
+
+// rectangle renders the rectangle to an image.
+func rectangle(r image.Rectangle, opts *RectangleOptions) Component {
 
 	// add margin
 	x, y, w, h := r.Min.X, r.Min.Y, r.Dx(), r.Dy()
@@ -215,11 +228,9 @@ func Circle(x, y, r float64, opts *CircleOptions) Component {
 	return dCircle
 }
 
-// DynamicCircle creates a cicle componet which can change radius and position.
+// DynamicCircle creates a cicle componet which can change radius and position. A nil stroke color uses the text color
+// of the theme.
 func DynamicCircle(opts *CircleOptions) *DynamicCircleComponent {
-	if opts.Stroke.Color == nil {
-		opts.Stroke.Color = color.Black
-	}
 	return &DynamicCircleComponent{opts, 0, 0, float32(opts.Radius)}
 }
 
@@ -239,13 +250,27 @@ func (d *DynamicCircleComponent) SetPosition(x, y float64) {
 	d.x, d.y = float32(x), float32(y)
 }
 
+// SetFillColor sets the fill color of the circle.
+func (d *DynamicCircleComponent) SetFillColor(c color.Color) {
+	d.opts.FillColor = c
+}
+
+// SetStrokeColor sets the stroke color of the circle.
+func (d *DynamicCircleComponent) SetStrokeColor(c color.Color) {
+	d.opts.Stroke.Color = c
+}
+
 // Display draws the circle on the screen.
 func (d *DynamicCircleComponent) Display(ctx *DisplayContext) {
 	if d.radius == 0 {
 		return
 	}
 
-	c := RGBA(d.opts.Stroke.Color)
+	strokeColor := d.opts.Stroke.Color
+	if strokeColor == nil {
+		strokeColor = ThemeFromContext(ctx.Context()).Palette.Text
+	}
+	c := RGBA(strokeColor)
 	cr := float32(c.R) / 0xff
 	cg := float32(c.G) / 0xff
 	cb := float32(c.B) / 0xff
@@ -424,3 +449,12 @@ func RectVertices(px0, py0, px1, py1 int, c color.Color) ([]ebiten.Vertex, []uin
 		},
 	}, []uint16{0, 1, 2, 1, 2, 3}
 }
+
+// Measure returns the size of the circle including the stroke. The origin is the top left corner of the square
+// around the circle.
+func (d *DynamicCircleComponent) Measure(c Constraints) SizeHints {
+	size := int(math.Ceil(float64(2*d.radius) + float64(d.opts.Stroke.Width)))
+	hints := FixedSizeHints(size, size)
+	hints.Origin = image.Pt(int(math.Floor(float64(d.x)-float64(size)/2)), int(math.Floor(float64(d.y)-float64(size)/2)))
+	return hints
+}
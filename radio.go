@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// RadioGroupOptions contains the options for a radio group. Unset fonts and colors follow the theme and a zero size,
+// gap or spacing uses the defaults. The options are placed in a column unless Horizontal is set.
+type RadioGroupOptions struct {
+	Font       string
+	FontSize   float64
+	TextColor  color.Color
+	Size       int
+	Gap        int
+	Spacing    int
+	Horizontal bool
+	Selected   int
+	Disabled   bool
+
+	// OnChange is called with the index of the option whenever the user selects a different option.
+	OnChange func(index int)
+}
+
+// RadioGroup creates a themed group of mutually exclusive options at the position. An option is selected by clicking
+// it, the arrow keys move the selection while the group is focused and Space or Enter selects the highlighted option.
+// A negative Selected starts with no option selected.
+func RadioGroup(x, y int, labels []string, opts *RadioGroupOptions) *RadioGroupComponent {
+	spacing := opts.Spacing
+	if spacing <= 0 {
+		spacing = DefaultToggleGap
+	}
+	g := &RadioGroupComponent{
+		opts:     opts,
+		x:        x,
+		y:        y,
+		spacing:  spacing,
+		selected: -1,
+		disabled: opts.Disabled,
+		hover:    -1,
+		pressed:  -1,
+		outer:    DynamicCircle(&CircleOptions{}),
+		dot:      DynamicCircle(&CircleOptions{}),
+	}
+	if opts.Selected >= 0 && opts.Selected < len(labels) {
+		g.selected = opts.Selected
+		g.active = opts.Selected
+	}
+	g.items = make([]*toggleControl, len(labels))
+	for i := 0; i < len(labels); i++ {
+		item := &toggleControl{}
+		item.init(x, y, opts.Size, opts.Size, opts.Gap, opts.Disabled, &TextOptions{
+			Font:      opts.Font,
+			FontSize:  opts.FontSize,
+			TextColor: opts.TextColor,
+		}, labels[i])
+		g.items[i] = item
+	}
+	g.layout()
+	return g
+}
+
+// RadioGroupComponent is a group of mutually exclusive options.
+type RadioGroupComponent struct {
+	opts     *RadioGroupOptions
+	items    []*toggleControl
+	x, y     int
+	spacing  int
+	selected int
+	active   int
+	hover    int
+	pressed  int
+	disabled bool
+	focused  bool
+	outer    *DynamicCircleComponent
+	dot      *DynamicCircleComponent
+}
+
+// Selected returns the index of the selected option or -1 if no option is selected.
+func (g *RadioGroupComponent) Selected() int {
+	return g.selected
+}
+
+// SetSelected selects the option without calling OnChange. A negative index clears the selection.
+func (g *RadioGroupComponent) SetSelected(index int) *RadioGroupComponent {
+	if index >= len(g.items) {
+		return g
+	}
+	if index < 0 {
+		index = -1
+	} else {
+		g.active = index
+	}
+	g.selected = index
+	return g
+}
+
+// Select selects the option and calls OnChange if the selection changed.
+func (g *RadioGroupComponent) Select(index int) {
+	if index < 0 || index >= len(g.items) || index == g.selected {
+		return
+	}
+	g.selected = index
+	g.active = index
+	if g.opts.OnChange != nil {
+		g.opts.OnChange(index)
+	}
+}
+
+// Disabled returns true if the group ignores input.
+func (g *RadioGroupComponent) Disabled() bool {
+	return g.disabled
+}
+
+// SetDisabled enables or disables the group.
+func (g *RadioGroupComponent) SetDisabled(disabled bool) {
+	g.disabled = disabled
+	for i := 0; i < len(g.items); i++ {
+		g.items[i].SetDisabled(disabled)
+	}
+	if disabled {
+		g.pressed = -1
+	}
+}
+
+// SetPosition moves the group.
+func (g *RadioGroupComponent) SetPosition(x, y int) {
+	g.x, g.y = x, y
+	g.layout()
+}
+
+// Focused returns true if the group has keyboard focus.
+func (g *RadioGroupComponent) Focused() bool {
+	return g.focused
+}
+
+// OnFocus highlights the active option.
+func (g *RadioGroupComponent) OnFocus() {
+	g.focused = true
+}
+
+// OnBlur removes the highlight.
+func (g *RadioGroupComponent) OnBlur() {
+	g.focused = false
+}
+
+// Contains returns true if the point is within an option.
+func (g *RadioGroupComponent) Contains(x, y int) bool {
+	return g.indexAt(x, y) >= 0
+}
+
+// OnMouseEvent selects the option which is clicked.
+func (g *RadioGroupComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if g.disabled || evt.Button != ebiten.MouseButtonLeft {
+		return
+	}
+	switch evt.EventType {
+	case MousePressEvent:
+		g.pressed = g.indexAt(x, y)
+	case MouseReleaseEvent:
+		if g.pressed >= 0 && g.pressed == g.indexAt(x, y) {
+			g.Select(g.pressed)
+		}
+		g.pressed = -1
+	}
+}
+
+// OnMouseMove highlights the option under the mouse.
+func (g *RadioGroupComponent) OnMouseMove(x, y int) {
+	g.hover = g.indexAt(x, y)
+}
+
+// OnKeyEvent moves the selection with the arrow keys and selects the active option with Space or Enter.
+func (g *RadioGroupComponent) OnKeyEvent(evt KeyEvent) {
+	if g.disabled || evt.EventType == KeyReleaseEvent || len(g.items) == 0 {
+		return
+	}
+	switch evt.Key {
+	case ebiten.KeyUp, ebiten.KeyLeft:
+		g.Select((g.active - 1 + len(g.items)) % len(g.items))
+	case ebiten.KeyDown, ebiten.KeyRight:
+		g.Select((g.active + 1) % len(g.items))
+	case ebiten.KeySpace, ebiten.KeyEnter, ebiten.KeyKPEnter:
+		if evt.EventType == KeyPressEvent {
+			g.Select(g.active)
+		}
+	}
+}
+
+// Update updates the labels and places the options.
+func (g *RadioGroupComponent) Update(ctx *UpdateContext) error {
+	for i := 0; i < len(g.items); i++ {
+		if err := g.items[i].Update(ctx); err != nil {
+			return err
+		}
+	}
+	g.layout()
+	return nil
+}
+
+// Display renders the options.
+func (g *RadioGroupComponent) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	for i := 0; i < len(g.items); i++ {
+		item := g.items[i]
+		item.hover = i == g.hover
+		item.pressed = i == g.pressed
+		item.focused = g.focused && i == g.active
+		state := item.state(theme)
+
+		box := item.control()
+		r := float64(box.Dy()) / 2
+		cx, cy := float64(box.Min.X)+r, float64(box.Min.Y)+r
+
+		// outer ring
+		g.outer.SetFillColor(state.Border)
+		g.outer.SetRadius(r)
+		g.outer.SetPosition(cx, cy)
+		g.outer.Display(ctx)
+		g.outer.SetFillColor(state.Background)
+		g.outer.SetRadius(r - float64(theme.BorderWidth))
+		g.outer.Display(ctx)
+
+		// selection dot
+		if i == g.selected {
+			dot := theme.Palette.Primary
+			if item.disabled {
+				dot = theme.Widget.Disabled.Foreground
+			}
+			g.dot.SetFillColor(dot)
+			g.dot.SetRadius(r / 2)
+			g.dot.SetPosition(cx, cy)
+			g.dot.Display(ctx)
+		}
+		item.displayLabel(ctx)
+	}
+}
+
+// Measure returns the size of all the options.
+func (g *RadioGroupComponent) Measure(cons Constraints) SizeHints {
+	var r image.Rectangle
+	for i := 0; i < len(g.items); i++ {
+		r = r.Union(g.items[i].bounds())
+	}
+	return BoundsSizeHints(r)
+}
+
+// layout places the options in a row or a column.
+func (g *RadioGroupComponent) layout() {
+	x, y := g.x, g.y
+	for i := 0; i < len(g.items); i++ {
+		g.items[i].SetPosition(x, y)
+		size := g.items[i].bounds().Size()
+		if g.opts.Horizontal {
+			x += size.X + g.spacing
+		} else {
+			y += size.Y + g.spacing
+		}
+	}
+}
+
+// indexAt returns the index of the option at the point or -1 if there is none.
+func (g *RadioGroupComponent) indexAt(x, y int) int {
+	for i := 0; i < len(g.items); i++ {
+		if g.items[i].Contains(x, y) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// InputEventType enumerates the type of a recorded input event.
+type InputEventType string
+
+// These are the recorded input event types.
+const (
+	InputMove      InputEventType = "move"
+	InputPress     InputEventType = "press"
+	InputRelease   InputEventType = "release"
+	InputWheel     InputEventType = "wheel"
+	InputKeyDown   InputEventType = "keydown"
+	InputKeyUp     InputEventType = "keyup"
+	InputTextTyped InputEventType = "text"
+)
+
+// InputEvent is an input event recorded on a tick. Ticks start at 1 for the first update.
+type InputEvent struct {
+	Tick   int                `json:"tick"`
+	Type   InputEventType     `json:"type"`
+	X      int                `json:"x,omitempty"`
+	Y      int                `json:"y,omitempty"`
+	Button ebiten.MouseButton `json:"button,omitempty"`
+	Key    ebiten.Key         `json:"key,omitempty"`
+	WheelX float64            `json:"wheelX,omitempty"`
+	WheelY float64            `json:"wheelY,omitempty"`
+	Text   string             `json:"text,omitempty"`
+}
+
+// InputRecording stores the input events and the number of ticks recorded. Deltas holds the time between the ticks
+// measured by the clock of the recorder, before the clock was paused or scaled.
+type InputRecording struct {
+	Ticks  int             `json:"ticks"`
+	Events []InputEvent    `json:"events"`
+	Deltas []time.Duration `json:"deltas,omitempty"`
+}
+
+// LoadInputRecording reads a JSON input recording.
+func LoadInputRecording(r io.Reader) (*InputRecording, error) {
+	var rec InputRecording
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// LoadInputRecordingFile reads a JSON input recording from the file.
+func LoadInputRecordingFile(path string) (*InputRecording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadInputRecording(f)
+}
+
+// Save writes the recording as JSON.
+func (rec *InputRecording) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rec)
+}
+
+// SaveFile writes the recording as JSON to the file.
+func (rec *InputRecording) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := rec.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Replay creates a scripted input source which plays the recording back tick by tick. Use it as the display input,
+// with the clock from Clock, to reproduce a session exactly.
+func (rec *InputRecording) Replay() *ScriptedInput {
+	frames := make([]func(in *ScriptedInput), rec.Ticks)
+	events := rec.Events
+	for tick := 1; tick <= rec.Ticks; tick++ {
+		var frame []InputEvent
+		for len(events) > 0 && events[0].Tick <= tick {
+			frame = append(frame, events[0])
+			events = events[1:]
+		}
+		if len(frame) == 0 {
+			continue
+		}
+		frames[tick-1] = func(in *ScriptedInput) {
+			for _, evt := range frame {
+				evt.apply(in)
+			}
+		}
+	}
+	return NewScriptedInput().Script(frames...)
+}
+
+// Clock creates a virtual clock which advances by the recorded deltas, one per tick. Ticks beyond the recording, or of
+// a recording without deltas, advance by the duration of a tick at the maximum TPS.
+func (rec *InputRecording) Clock() *Clock {
+	return newStepClock(rec.Deltas)
+}
+
+// apply feeds the event to the scripted input.
+func (evt InputEvent) apply(in *ScriptedInput) {
+	switch evt.Type {
+	case InputMove:
+		in.MoveTo(evt.X, evt.Y)
+	case InputPress:
+		in.Press(evt.Button)
+	case InputRelease:
+		in.Release(evt.Button)
+	case InputWheel:
+		in.Scroll(evt.WheelX, evt.WheelY)
+	case InputKeyDown:
+		in.KeyDown(evt.Key)
+	case InputKeyUp:
+		in.KeyUp(evt.Key)
+	case InputTextTyped:
+		in.Type(evt.Text)
+	}
+}
+
+// NewInputRecorder creates an input source which records the input of the source.
+func NewInputRecorder(src InputSource) *InputRecorder {
+	return &InputRecorder{InputSource: src, rec: &InputRecording{}}
+}
+
+// InputRecorder records the changes in the input of its source on every tick while passing the input through. With a
+// clock the time between ticks is recorded as well. A display sets its clock on a recorder used as its input.
+type InputRecorder struct {
+	InputSource
+	rec   *InputRecording
+	clock *Clock
+	x, y  int
+}
+
+// SetClock records the time between the ticks of the clock. The clock must tick before the recorder is updated.
+func (r *InputRecorder) SetClock(c *Clock) *InputRecorder {
+	r.clock = c
+	return r
+}
+
+// Recording returns the input recorded so far.
+func (r *InputRecorder) Recording() *InputRecording {
+	return r.rec
+}
+
+// Update updates the source and records the changes.
+func (r *InputRecorder) Update() {
+	r.InputSource.Update()
+	r.rec.Ticks++
+	tick := r.rec.Ticks
+	if r.clock != nil {
+		r.rec.Deltas = append(r.rec.Deltas, r.clock.tick)
+	}
+
+	if x, y := r.CursorPosition(); tick == 1 || x != r.x || y != r.y {
+		r.x, r.y = x, y
+		r.record(InputEvent{Tick: tick, Type: InputMove, X: x, Y: y})
+	}
+	for _, b := range mouseButtons {
+		if r.IsMouseButtonJustPressed(b) {
+			r.record(InputEvent{Tick: tick, Type: InputPress, Button: b})
+		}
+		if r.IsMouseButtonJustReleased(b) {
+			r.record(InputEvent{Tick: tick, Type: InputRelease, Button: b})
+		}
+	}
+	if wx, wy := r.Wheel(); wx != 0 || wy != 0 {
+		r.record(InputEvent{Tick: tick, Type: InputWheel, WheelX: wx, WheelY: wy})
+	}
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		if r.IsKeyJustPressed(k) {
+			r.record(InputEvent{Tick: tick, Type: InputKeyDown, Key: k})
+		}
+		if r.IsKeyJustReleased(k) {
+			r.record(InputEvent{Tick: tick, Type: InputKeyUp, Key: k})
+		}
+	}
+	if chars := r.InputChars(); len(chars) > 0 {
+		r.record(InputEvent{Tick: tick, Type: InputTextTyped, Text: string(chars)})
+	}
+}
+
+func (r *InputRecorder) record(evt InputEvent) {
+	r.rec.Events = append(r.rec.Events, evt)
+}
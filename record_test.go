@@ -0,0 +1,78 @@
+package ui_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/eliquious/ui"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestInputRecordingReplay(t *testing.T) {
+	in := ui.NewScriptedInput()
+	clock := ui.NewVirtualClock(10 * time.Millisecond)
+	recorder := ui.NewInputRecorder(in).SetClock(clock)
+
+	in.Script(
+		func(in *ui.ScriptedInput) { in.MoveTo(10, 20) },
+		func(in *ui.ScriptedInput) { in.Press(ebiten.MouseButtonLeft) },
+		nil,
+		func(in *ui.ScriptedInput) { in.MoveTo(30, 40).Release(ebiten.MouseButtonLeft).Type("a") },
+	)
+
+	// the first ticks are uneven
+	type tick struct {
+		x, y              int
+		pressed, released bool
+		chars             string
+		delta             time.Duration
+	}
+	var recorded []tick
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Duration(i) * time.Millisecond)
+		clock.Tick()
+		recorder.Update()
+		x, y := recorder.CursorPosition()
+		recorded = append(recorded, tick{
+			x, y,
+			recorder.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+			recorder.IsMouseButtonJustReleased(ebiten.MouseButtonLeft),
+			string(recorder.InputChars()),
+			clock.Delta(),
+		})
+	}
+
+	// the recording survives a round trip through JSON
+	var buf bytes.Buffer
+	if err := recorder.Recording().Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := ui.LoadInputRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Ticks != 5 || len(rec.Deltas) != 5 {
+		t.Fatalf("expected 5 ticks and deltas; got %d ticks and %d deltas", rec.Ticks, len(rec.Deltas))
+	}
+
+	replay, replayClock := rec.Replay(), rec.Clock()
+	for i := 0; i < len(recorded); i++ {
+		replayClock.Tick()
+		replay.Update()
+		x, y := replay.CursorPosition()
+		actual := tick{
+			x, y,
+			replay.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+			replay.IsMouseButtonJustReleased(ebiten.MouseButtonLeft),
+			string(replay.InputChars()),
+			replayClock.Delta(),
+		}
+		if actual != recorded[i] {
+			t.Errorf("tick %d: expected %+v; got %+v", i+1, recorded[i], actual)
+		}
+	}
+	if elapsed := replayClock.Elapsed(); elapsed != clock.Elapsed() {
+		t.Errorf("expected the replay to take %s; got %s", clock.Elapsed(), elapsed)
+	}
+}
@@ -0,0 +1,447 @@
+package ui
+
+import (
+	"image"
+	"sort"
+)
+
+// NewNode creates a scene graph node for the component. The component may be nil for grouping nodes.
+func NewNode(c Component) *Node {
+	return &Node{component: c, visible: true}
+}
+
+// Node is a node in the retained scene graph. Each node is positioned relative to its parent and its children are
+// drawn above it in z-index order.
+//
+// A node is hit when the point is within the bounds set with SetBounds. Nodes without bounds whose component is
+// Hittable are hit when the component contains the point. Nodes with neither are never hit and instead receive every
+// mouse event, which keeps global listeners such as the FPS display working.
+type Node struct {
+	component Component
+	parent    *Node
+	children  []*Node
+
+	x, y    float64
+	bounds  image.Rectangle
+	zIndex  int
+	visible bool
+	sorted  bool
+
+	listeners        []MouseEventListener
+	captureListeners []MouseCaptureListener
+
+	// pointer capture and default actions for the root node
+	captured         *Node
+	hovered          *Node
+	defaultHandlers  []MouseButtonHandler
+	defaultListeners []MouseEventListener
+}
+
+// Component returns the component of the node.
+func (n *Node) Component() Component {
+	return n.component
+}
+
+// Parent returns the parent node or nil for the root.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns the child nodes in z-index order.
+func (n *Node) Children() []*Node {
+	n.sortChildren()
+	return n.children
+}
+
+// Add adds child nodes. Nodes which already have a parent are moved.
+func (n *Node) Add(children ...*Node) *Node {
+	for _, c := range children {
+		if c.parent != nil {
+			c.parent.Remove(c)
+		}
+		c.parent = n
+		n.children = append(n.children, c)
+	}
+	n.sorted = false
+	return n
+}
+
+// Remove removes a child node.
+func (n *Node) Remove(child *Node) *Node {
+	for i := 0; i < len(n.children); i++ {
+		if n.children[i] == child {
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			child.parent = nil
+			break
+		}
+	}
+	return n
+}
+
+// AddMouseListener adds a listener for mouse events in the target and bubble phases.
+func (n *Node) AddMouseListener(l MouseEventListener) *Node {
+	n.listeners = append(n.listeners, l)
+	return n
+}
+
+// AddMouseCaptureListener adds a listener for mouse events in the capture phase.
+func (n *Node) AddMouseCaptureListener(l MouseCaptureListener) *Node {
+	n.captureListeners = append(n.captureListeners, l)
+	return n
+}
+
+// AddDefaultMouseHandler adds a handler which performs the default action of mouse button events. Default handlers are
+// called with screen coordinates after the event has propagated unless PreventDefault was called.
+func (n *Node) AddDefaultMouseHandler(h MouseButtonHandler) *Node {
+	n.defaultHandlers = append(n.defaultHandlers, h)
+	return n
+}
+
+// AddDefaultMouseListener adds a listener which performs the default action of mouse button events. Default listeners
+// are called after the event has propagated unless PreventDefault was called, and receive the event with its target.
+func (n *Node) AddDefaultMouseListener(l MouseEventListener) *Node {
+	n.defaultListeners = append(n.defaultListeners, l)
+	return n
+}
+
+// SetPosition sets the position of the node relative to its parent.
+func (n *Node) SetPosition(x, y float64) *Node {
+	n.x, n.y = x, y
+	return n
+}
+
+// Position returns the position of the node relative to its parent.
+func (n *Node) Position() (float64, float64) {
+	return n.x, n.y
+}
+
+// ScreenPosition returns the position of the node on the screen.
+func (n *Node) ScreenPosition() (float64, float64) {
+	x, y := n.x, n.y
+	for p := n.parent; p != nil; p = p.parent {
+		x += p.x
+		y += p.y
+	}
+	return x, y
+}
+
+// SetBounds sets the hit test bounds of the node relative to its position.
+func (n *Node) SetBounds(r image.Rectangle) *Node {
+	n.bounds = r
+	return n
+}
+
+// Bounds returns the hit test bounds of the node relative to its position.
+func (n *Node) Bounds() image.Rectangle {
+	return n.bounds
+}
+
+// ScreenBounds returns the hit test bounds of the node on the screen.
+func (n *Node) ScreenBounds() image.Rectangle {
+	x, y := n.ScreenPosition()
+	return n.bounds.Add(image.Pt(int(x), int(y)))
+}
+
+// SetZIndex sets the drawing order of the node among its siblings. Nodes with a higher z-index are drawn above and hit
+// first. Siblings with the same z-index are drawn in the order they were added.
+func (n *Node) SetZIndex(z int) *Node {
+	n.zIndex = z
+	if n.parent != nil {
+		n.parent.sorted = false
+	}
+	return n
+}
+
+// ZIndex returns the z-index of the node.
+func (n *Node) ZIndex() int {
+	return n.zIndex
+}
+
+// SetVisible shows or hides the node and its children. Hidden nodes are not updated, drawn or hit.
+func (n *Node) SetVisible(visible bool) *Node {
+	n.visible = visible
+	return n
+}
+
+// Visible returns whether the node is visible.
+func (n *Node) Visible() bool {
+	return n.visible
+}
+
+// Walk calls the function for the node and all its descendants in drawing order.
+func (n *Node) Walk(fn func(*Node)) {
+	fn(n)
+	for _, c := range n.Children() {
+		c.Walk(fn)
+	}
+}
+
+// Update updates the component and the children. The node is available to its component from UpdateContext.Node.
+func (n *Node) Update(ctx *UpdateContext) error {
+	if !n.visible {
+		return nil
+	}
+	if n.component != nil {
+		node := ctx.node
+		ctx.node = n
+		err := n.component.Update(ctx)
+		ctx.node = node
+		if err != nil {
+			return err
+		}
+	}
+	for _, c := range n.Children() {
+		if err := c.Update(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Display draws the component and then the children in z-index order.
+func (n *Node) Display(ctx *DisplayContext) {
+	if !n.visible {
+		return
+	}
+	ctx = ctx.Translate(n.x, n.y)
+	if n.component != nil {
+		n.component.Display(ctx)
+	}
+	for _, c := range n.Children() {
+		c.Display(ctx)
+	}
+}
+
+// HitTest returns the topmost visible node containing the screen point or nil if no node is hit.
+func (n *Node) HitTest(x, y int) *Node {
+	if !n.visible {
+		return nil
+	}
+
+	// children are above the node
+	children := n.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if hit := children[i].HitTest(x, y); hit != nil {
+			return hit
+		}
+	}
+	if n.contains(x, y) {
+		return n
+	}
+	return nil
+}
+
+// contains returns true if the node is hit by the screen point.
+func (n *Node) contains(x, y int) bool {
+	if !n.bounds.Empty() {
+		return image.Pt(x, y).In(n.ScreenBounds())
+	}
+	if h, ok := n.component.(Hittable); ok {
+		lx, ly := n.local(x, y)
+		return h.Contains(lx, ly)
+	}
+	return false
+}
+
+// hittable returns true if the node has bounds or a hittable component.
+func (n *Node) hittable() bool {
+	if !n.bounds.Empty() {
+		return true
+	}
+	_, ok := n.component.(Hittable)
+	return ok
+}
+
+// local converts a screen point into the coordinate space of the node.
+func (n *Node) local(x, y int) (int, int) {
+	sx, sy := n.ScreenPosition()
+	return x - int(sx), y - int(sy)
+}
+
+// OnMouseEvent dispatches the mouse event to the topmost node under the mouse. The node which receives a press also
+// receives the drag events and the matching release, even if the mouse has moved off of it. Drops are sent to the node
+// under the mouse.
+func (n *Node) OnMouseEvent(x, y int, evt MouseEvent) {
+	target := n.HitTest(x, y)
+
+	// the node which received the press receives the drag and release
+	switch evt.EventType {
+	case MouseDragStartEvent, MouseDragEvent, MouseReleaseEvent:
+		if n.captured != nil {
+			target = n.captured
+		}
+	}
+	if evt.EventType == MousePressEvent {
+		n.captured = target
+	} else if evt.EventType == MouseReleaseEvent {
+		n.captured = nil
+	}
+
+	pe := &PointerEvent{MouseEvent: evt, X: x, Y: y}
+	n.Dispatch(target, pe)
+	n.broadcast(func(t *Node) {
+		if h, ok := t.component.(MouseButtonHandler); ok {
+			lx, ly := t.local(x, y)
+			h.OnMouseEvent(lx, ly, evt)
+		}
+	})
+
+	// default actions
+	if !pe.DefaultPrevented() {
+		for i := 0; i < len(n.defaultHandlers); i++ {
+			n.defaultHandlers[i].OnMouseEvent(x, y, evt)
+		}
+		pe.Target, pe.CurrentTarget, pe.Phase = target, nil, NoPhase
+		for i := 0; i < len(n.defaultListeners); i++ {
+			n.defaultListeners[i].HandleMouseEvent(pe)
+		}
+	}
+}
+
+// OnMouseMove dispatches the move to the topmost node under the mouse and to the node the mouse has just left. While a
+// button is held, moves are also sent to the node which received the press. Enter and leave events are sent to the
+// nodes the mouse enters and leaves without propagating.
+func (n *Node) OnMouseMove(x, y int) {
+	target := n.HitTest(x, y)
+
+	move := func(t *Node) {
+		for ; t != nil; t = t.parent {
+			if h, ok := t.component.(MouseMoveHandler); ok && t.hittable() {
+				lx, ly := t.local(x, y)
+				h.OnMouseMove(lx, ly)
+				return
+			}
+		}
+	}
+	move(target)
+	if n.hovered != nil && n.hovered != target {
+		move(n.hovered)
+	}
+	if n.captured != nil && n.captured != target && n.captured != n.hovered {
+		move(n.captured)
+	}
+	if n.hovered != target {
+		if n.hovered != nil {
+			n.notify(n.hovered, &PointerEvent{MouseEvent: MouseEvent{Button: MouseButtonNone, EventType: MouseLeaveEvent}, X: x, Y: y})
+		}
+		if target != nil {
+			n.notify(target, &PointerEvent{MouseEvent: MouseEvent{Button: MouseButtonNone, EventType: MouseEnterEvent}, X: x, Y: y})
+		}
+	}
+	n.hovered = target
+
+	n.Dispatch(target, &PointerEvent{MouseEvent: MouseEvent{Button: MouseButtonNone, EventType: MouseMoveEvent}, X: x, Y: y})
+	n.broadcast(func(t *Node) {
+		if h, ok := t.component.(MouseMoveHandler); ok {
+			lx, ly := t.local(x, y)
+			h.OnMouseMove(lx, ly)
+		}
+	})
+}
+
+// Dispatch propagates the event to the target. Capture listeners of the ancestors are called from the root down, then
+// the listeners of the target and finally the listeners of the ancestors from the parent of the target up to the root.
+// Components which only implement MouseButtonHandler consume the button events they receive.
+func (n *Node) Dispatch(target *Node, evt *PointerEvent) {
+	if target == nil {
+		return
+	}
+	evt.Target = target
+
+	// ancestors from the root down
+	var path []*Node
+	for p := target.parent; p != nil; p = p.parent {
+		path = append([]*Node{p}, path...)
+	}
+
+	evt.Phase = CapturePhase
+	for i := 0; i < len(path); i++ {
+		if path[i].capture(evt) {
+			return
+		}
+	}
+
+	evt.Phase = TargetPhase
+	if target.handle(evt) {
+		return
+	}
+
+	evt.Phase = BubblePhase
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].handle(evt) {
+			return
+		}
+	}
+	evt.Phase = NoPhase
+}
+
+// notify sends the event to the target only.
+func (n *Node) notify(target *Node, evt *PointerEvent) {
+	evt.Target = target
+	evt.Phase = TargetPhase
+	target.handle(evt)
+	evt.Phase = NoPhase
+}
+
+// capture calls the capture listeners of the node and returns true if propagation was stopped.
+func (n *Node) capture(evt *PointerEvent) bool {
+	n.setCurrentTarget(evt)
+	if l, ok := n.component.(MouseCaptureListener); ok && l.CaptureMouseEvent(evt) {
+		evt.StopPropagation()
+	}
+	for i := 0; i < len(n.captureListeners); i++ {
+		if n.captureListeners[i].CaptureMouseEvent(evt) {
+			evt.StopPropagation()
+		}
+	}
+	return evt.PropagationStopped()
+}
+
+// handle calls the listeners of the node and returns true if propagation was stopped.
+func (n *Node) handle(evt *PointerEvent) bool {
+	n.setCurrentTarget(evt)
+	if l, ok := n.component.(MouseEventListener); ok {
+		if l.HandleMouseEvent(evt) {
+			evt.StopPropagation()
+		}
+	} else if h, ok := n.component.(MouseButtonHandler); ok && n.hittable() && evt.EventType != MouseMoveEvent {
+		h.OnMouseEvent(evt.LocalX, evt.LocalY, evt.MouseEvent)
+		evt.StopPropagation()
+	}
+	for i := 0; i < len(n.listeners); i++ {
+		if n.listeners[i].HandleMouseEvent(evt) {
+			evt.StopPropagation()
+		}
+	}
+	return evt.PropagationStopped()
+}
+
+// setCurrentTarget makes the node the current target of the event.
+func (n *Node) setCurrentTarget(evt *PointerEvent) {
+	evt.CurrentTarget = n
+	evt.LocalX, evt.LocalY = n.local(evt.X, evt.Y)
+}
+
+// broadcast calls the function for every visible node which cannot be hit.
+func (n *Node) broadcast(fn func(*Node)) {
+	if !n.visible {
+		return
+	}
+	if n.component != nil && !n.hittable() {
+		fn(n)
+	}
+	for _, c := range n.Children() {
+		c.broadcast(fn)
+	}
+}
+
+// sortChildren orders the children by z-index.
+func (n *Node) sortChildren() {
+	if n.sorted {
+		return
+	}
+	sort.SliceStable(n.children, func(i, j int) bool {
+		return n.children[i].zIndex < n.children[j].zIndex
+	})
+	n.sorted = true
+}
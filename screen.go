@@ -8,7 +8,6 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 var (
@@ -26,6 +25,9 @@ func IsHighDPIEnabled() bool {
 	return highDPI
 }
 
+// OverlayZIndex is the z-index of the overlay node within the scene.
+const OverlayZIndex = 1 << 30
+
 // DisplaySettings stores the display settings.
 type DisplaySettings struct {
 	Title           string
@@ -34,6 +36,18 @@ type DisplaySettings struct {
 	BackgroundColor color.Color
 	HideCursor      bool
 	Debug           bool
+
+	// Input replaces the mouse and keyboard. Displays with an input source have their own event registries.
+	Input InputSource
+
+	// Capture enables starting and stopping frame capture with the hot key.
+	Capture *CaptureOptions
+
+	// Clock replaces the wall clock, for example with a virtual clock in tests.
+	Clock *Clock
+
+	// Theme sets the theme of the display. Without a background color the screen is filled with the theme background.
+	Theme *Theme
 }
 
 // New creates a new screen
@@ -46,20 +60,70 @@ func New(ctx context.Context, settings *DisplaySettings) *Display {
 		ebiten.SetCursorMode(ebiten.CursorModeHidden)
 	}
 
-	display := &Display{ctx: ctx, settings: settings, mouseEventRegistry: DefaultMouseEventRegistry}
+	display := &Display{
+		ctx:                   ctx,
+		settings:              settings,
+		mouseEventRegistry:    NewMouseEventRegistry(0, 0),
+		keyboardEventRegistry: NewKeyboardEventRegistry(),
+		input:                 EbitenInput,
+		clock:                 settings.Clock,
+		focusManager:          NewFocusManager(),
+		scene:                 NewNode(nil),
+	}
+
+	// the overlay layers are above the components
+	overlay := NewNode(nil).SetZIndex(OverlayZIndex)
+	display.scene.Add(overlay)
+	display.overlays = NewOverlayManager(overlay, display.focusManager)
+	display.overlays.SetScreenSize(settings.Width, settings.Height)
+
+	if display.clock == nil {
+		display.clock = NewClock()
+	}
+	if r, ok := settings.Input.(*InputRecorder); ok && r.clock == nil {
+		r.SetClock(display.clock)
+	}
+	if settings.Theme != nil {
+		display.SetTheme(settings.Theme)
+	}
+
+	// every display has its own registries so handlers are never shared between displays
+	if settings.Input != nil {
+		display.input = settings.Input
+		display.mouseEventRegistry.SetInput(settings.Input)
+		display.keyboardEventRegistry.SetInput(settings.Input)
+	}
+
+	// route mouse input through the scene graph
+	display.AddMouseButtonHandler(display.scene)
+	display.AddMouseMoveHandler(display.scene)
+
+	// focus the component under the mouse unless the event was prevented
+	display.scene.AddDefaultMouseListener(display.focusManager)
+
+	// route keyboard input through the focus manager
+	display.AddKeyHandler(display.focusManager)
+	display.AddTextInputHandler(display.focusManager)
 	return display
 }
 
 // Display represents a display screen
 type Display struct {
-	ctx                context.Context
-	settings           *DisplaySettings
-	mouseEventRegistry *MouseEventRegistry
+	ctx                   context.Context
+	settings              *DisplaySettings
+	mouseEventRegistry    *MouseEventRegistry
+	keyboardEventRegistry *KeyboardEventRegistry
+	input                 InputSource
+	clock                 *Clock
+	theme                 *Theme
+	focusManager          *FocusManager
 
 	cursor         Component
 	background     Component
-	components     []Component
+	scene          *Node
+	overlays       *OverlayManager
 	updateHandlers []UpdateHandler
+	capture        *frameCapture
 }
 
 // Layout takes the outside size (e.g., the window size) and returns the (logical) screen size.
@@ -69,27 +133,80 @@ func (d *Display) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHe
 	// scale the display if highdpi is enabled
 	if highDPI {
 		scale := ebiten.DeviceScaleFactor()
-		return int(float64(outsideWidth) * scale), int(float64(outsideHeight) * scale)
+		screenWidth, screenHeight = int(float64(outsideWidth)*scale), int(float64(outsideHeight)*scale)
+	} else {
+		screenWidth, screenHeight = outsideWidth, outsideHeight
 	}
-	return outsideWidth, outsideHeight
+
+	// keep popups within the screen
+	d.overlays.SetScreenSize(screenWidth, screenHeight)
+	return screenWidth, screenHeight
 }
 
-// Add adds display components to the display.
+// Add adds display components to the display. Each component is added to the scene graph as a node unless it is
+// already a node. Mouse events are dispatched through the scene graph to the topmost node under the mouse.
 func (d *Display) Add(c ...Component) *Display {
-	d.components = append(d.components, c...)
-
-	// add mouse handlers
 	for i := 0; i < len(c); i++ {
-		if h, ok := c[i].(MouseButtonHandler); ok {
-			d.AddMouseButtonHandler(h)
-		}
-		if h, ok := c[i].(MouseMoveHandler); ok {
-			d.AddMouseMoveHandler(h)
+		n, ok := c[i].(*Node)
+		if !ok {
+			n = NewNode(c[i])
 		}
+		d.scene.Add(n)
+
+		// add keyboard handlers for the components in the node
+		n.Walk(func(n *Node) {
+			if n.component != nil {
+				d.addKeyboardHandlers(n.component)
+			}
+		})
 	}
 	return d
 }
 
+// componentGroup is implemented by components which contain components that are not nodes, such as containers.
+type componentGroup interface {
+	components() []Component
+}
+
+// addKeyboardHandlers registers the component, and the components it contains, for keyboard input.
+func (d *Display) addKeyboardHandlers(c Component) {
+	if g, ok := c.(componentGroup); ok {
+		children := g.components()
+		for i := 0; i < len(children); i++ {
+			d.addKeyboardHandlers(children[i])
+		}
+	}
+
+	// focusable components only receive keyboard input while focused
+	if f, ok := c.(Focusable); ok {
+		d.focusManager.Add(f)
+		return
+	}
+	if h, ok := c.(KeyHandler); ok {
+		d.AddKeyHandler(h)
+	}
+	if h, ok := c.(TextInputHandler); ok {
+		d.AddTextInputHandler(h)
+	}
+}
+
+// Scene returns the root node of the scene graph.
+func (d *Display) Scene() *Node {
+	return d.scene
+}
+
+// Overlay returns the root node of the overlay layers. It is a child of the scene with OverlayZIndex, so it is drawn
+// after and hit before the components added to the display.
+func (d *Display) Overlay() *Node {
+	return d.overlays.Root()
+}
+
+// Overlays returns the overlay layers for popups, modal dialogs, menus and tooltips. The layers are within a node of the
+// scene with OverlayZIndex, so they are drawn after and hit before the components added to the display.
+func (d *Display) Overlays() *OverlayManager {
+	return d.overlays
+}
+
 // AddMouseButtonHandler adds a mouse handler to the screen.
 func (d *Display) AddMouseButtonHandler(h MouseButtonHandler) *Display {
 	d.mouseEventRegistry.AddButtonHandler(h)
@@ -108,6 +225,43 @@ func (d *Display) AddMouseMoveHandler(h MouseMoveHandler) *Display {
 	return d
 }
 
+// AddKeyHandler adds a key handler to the screen.
+func (d *Display) AddKeyHandler(h KeyHandler) *Display {
+	d.keyboardEventRegistry.AddKeyHandler(h)
+	return d
+}
+
+// AddTextInputHandler adds a text input handler to the screen.
+func (d *Display) AddTextInputHandler(h TextInputHandler) *Display {
+	d.keyboardEventRegistry.AddTextInputHandler(h)
+	return d
+}
+
+// SetTheme switches the theme. Components with themed options follow the new theme from the next update.
+func (d *Display) SetTheme(t *Theme) *Display {
+	d.theme = t
+	d.ctx = WithTheme(d.ctx, t)
+	return d
+}
+
+// Theme returns the theme of the display.
+func (d *Display) Theme() *Theme {
+	if d.theme == nil {
+		return DefaultTheme
+	}
+	return d.theme
+}
+
+// Clock returns the game clock.
+func (d *Display) Clock() *Clock {
+	return d.clock
+}
+
+// FocusManager returns the focus manager which routes keyboard input to the focused component.
+func (d *Display) FocusManager() *FocusManager {
+	return d.focusManager
+}
+
 // SetCursor sets the display component for the cursor.
 func (d *Display) SetCursor(c Component) *Display {
 	d.cursor = c
@@ -123,14 +277,30 @@ func (d *Display) SetBackground(c Component) *Display {
 // Update proceeds the game state.
 // Update is called every tick (1/60 [s] by default).
 func (d *Display) Update() error {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		return errors.New("user exit")
+	d.clock.Tick()
+	d.input.Update()
+
+	// escape closes the top popup and exits when there is none
+	if d.input.IsKeyJustPressed(ebiten.KeyEscape) {
+		if p := d.overlays.Top(); p != nil {
+			p.Close()
+		} else {
+			d.stopCaptureOnExit()
+			return errors.New("user exit")
+		}
 	}
+	d.updateCapture()
 	ctx := NewUpdateContext(d.ctx)
+	ctx.input = d.input
+	ctx.clock = d.clock
+	ctx.overlays = d.overlays
 
 	// update the mouse event registry
 	d.mouseEventRegistry.Update()
 
+	// update the keyboard event registry
+	d.keyboardEventRegistry.Update()
+
 	// call all update handlers
 	for i := 0; i < len(d.updateHandlers); i++ {
 		if err := d.updateHandlers[i].Update(ctx); err != nil {
@@ -146,10 +316,8 @@ func (d *Display) Update() error {
 	}
 
 	// update components
-	for _, c := range d.components {
-		if err := c.Update(ctx); err != nil {
-			return err
-		}
+	if err := d.scene.Update(ctx); err != nil {
+		return err
 	}
 
 	// draw cursor
@@ -167,18 +335,19 @@ func (d *Display) Update() error {
 func (d *Display) Draw(screen *ebiten.Image) {
 	if d.settings.BackgroundColor != nil {
 		screen.Fill(d.settings.BackgroundColor)
+	} else if d.theme != nil {
+		screen.Fill(d.theme.Palette.Background)
 	}
 
 	ctx := NewDisplayContext(d.ctx, screen)
+	ctx.input = d.input
 	// draw background component
 	if d.background != nil {
 		d.background.Display(ctx)
 	}
 
 	// draw components
-	for _, c := range d.components {
-		c.Display(ctx)
-	}
+	d.scene.Display(ctx)
 
 	// draw cursor
 	if d.cursor != nil {
@@ -186,12 +355,17 @@ func (d *Display) Draw(screen *ebiten.Image) {
 	}
 
 	if d.settings.Debug {
-		mouseX, mouseY := ebiten.CursorPosition()
+		mouseX, mouseY := d.input.CursorPosition()
 		ebitenutil.DebugPrint(screen, fmt.Sprintf("FPS: %.2f MouseX: %d MouseY: %d", ebiten.CurrentFPS(), mouseX, mouseY))
 	}
+
+	// capture the finished frame
+	d.captureFrame(screen)
 }
 
-// Show shows the window
+// Show shows the window. A running capture is finished when the display exits.
 func (d *Display) Show() error {
-	return ebiten.RunGame(d)
+	err := ebiten.RunGame(d)
+	d.stopCaptureOnExit()
+	return err
 }
@@ -0,0 +1,459 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// These are the default sizes of the slider in pixels.
+const (
+	DefaultSliderTrackWidth   = 4
+	DefaultSliderHandleRadius = 8
+)
+
+// SliderOptions contains the options for a slider. Values are kept between Min and Max, which default to 0 and 1, and
+// rounded to a multiple of Step from Min unless Step is zero. Unset colors and fonts follow the theme. The value label
+// is shown after the track when ShowValue is set and is formatted with Format, which defaults to "%g".
+type SliderOptions struct {
+	Min, Max     float64
+	Step         float64
+	Value        float64
+	Vertical     bool
+	TrackWidth   int
+	HandleRadius float64
+	TrackColor   color.Color
+	FillColor    color.Color
+	HandleColor  color.Color
+	ShowValue    bool
+	Format       string
+	Font         string
+	FontSize     float64
+	TextColor    color.Color
+	Disabled     bool
+
+	// OnChange is called whenever the value is changed by the user.
+	OnChange func(value float64)
+}
+
+// Slider creates a themed slider whose track runs along the rectangle. The handle is dragged with the mouse, clicking
+// the track jumps the handle to the point and the arrow keys, Page Up, Page Down, Home and End move it while the slider
+// is focused. Vertical sliders have their minimum at the bottom.
+func Slider(r image.Rectangle, opts *SliderOptions) *SliderComponent {
+	s := &SliderComponent{}
+	s.init(r, sliderStyle{
+		min: opts.Min, max: opts.Max, step: opts.Step, vertical: opts.Vertical,
+		trackWidth: opts.TrackWidth, handleRadius: opts.HandleRadius,
+		trackColor: opts.TrackColor, fillColor: opts.FillColor, handleColor: opts.HandleColor,
+		showValue: opts.ShowValue, format: opts.Format, disabled: opts.Disabled,
+		text: &TextOptions{Font: opts.Font, FontSize: opts.FontSize, TextColor: opts.TextColor},
+	}, opts.Value)
+	s.changed = func() {
+		if opts.OnChange != nil {
+			opts.OnChange(s.values[0])
+		}
+	}
+	return s
+}
+
+// SliderComponent is a slider with one handle.
+type SliderComponent struct {
+	sliderTrack
+}
+
+// Value returns the value of the slider.
+func (s *SliderComponent) Value() float64 {
+	return s.values[0]
+}
+
+// SetValue sets the value without calling OnChange.
+func (s *SliderComponent) SetValue(v float64) *SliderComponent {
+	s.values[0] = s.snap(v)
+	return s
+}
+
+// RangeSliderOptions contains the options for a range slider. The options are the same as for a slider with a low and
+// a high value instead of one.
+type RangeSliderOptions struct {
+	Min, Max     float64
+	Step         float64
+	Low, High    float64
+	Vertical     bool
+	TrackWidth   int
+	HandleRadius float64
+	TrackColor   color.Color
+	FillColor    color.Color
+	HandleColor  color.Color
+	ShowValue    bool
+	Format       string
+	Font         string
+	FontSize     float64
+	TextColor    color.Color
+	Disabled     bool
+
+	// OnChange is called whenever either value is changed by the user.
+	OnChange func(low, high float64)
+}
+
+// RangeSlider creates a themed slider with two handles which select a range. The handle nearest to the mouse is
+// dragged or jumps to a click on the track and the handles cannot pass each other. The keyboard moves the handle which
+// was last moved, starting with the low handle.
+func RangeSlider(r image.Rectangle, opts *RangeSliderOptions) *RangeSliderComponent {
+	s := &RangeSliderComponent{}
+	s.init(r, sliderStyle{
+		min: opts.Min, max: opts.Max, step: opts.Step, vertical: opts.Vertical,
+		trackWidth: opts.TrackWidth, handleRadius: opts.HandleRadius,
+		trackColor: opts.TrackColor, fillColor: opts.FillColor, handleColor: opts.HandleColor,
+		showValue: opts.ShowValue, format: opts.Format, disabled: opts.Disabled,
+		text: &TextOptions{Font: opts.Font, FontSize: opts.FontSize, TextColor: opts.TextColor},
+	}, opts.Low, opts.High)
+	s.changed = func() {
+		if opts.OnChange != nil {
+			opts.OnChange(s.values[0], s.values[1])
+		}
+	}
+	return s
+}
+
+// RangeSliderComponent is a slider with a low and a high handle.
+type RangeSliderComponent struct {
+	sliderTrack
+}
+
+// Range returns the low and high values.
+func (s *RangeSliderComponent) Range() (float64, float64) {
+	return s.values[0], s.values[1]
+}
+
+// SetRange sets the low and high values without calling OnChange. The values are swapped if low is above high.
+func (s *RangeSliderComponent) SetRange(low, high float64) *RangeSliderComponent {
+	if low > high {
+		low, high = high, low
+	}
+	s.values[0], s.values[1] = s.snap(low), s.snap(high)
+	return s
+}
+
+// sliderStyle is the configuration shared by the sliders.
+type sliderStyle struct {
+	min, max, step float64
+	vertical       bool
+	trackWidth     int
+	handleRadius   float64
+	trackColor     color.Color
+	fillColor      color.Color
+	handleColor    color.Color
+	showValue      bool
+	format         string
+	disabled       bool
+	text           *TextOptions
+}
+
+// sliderTrack implements the sliders for any number of handles, which are kept in increasing order.
+type sliderTrack struct {
+	r       image.Rectangle
+	style   sliderStyle
+	values  []float64
+	changed func()
+
+	label    *DynamicTextComponent
+	handle   *DynamicCircleComponent
+	active   int
+	dragging bool
+	hover    bool
+	focused  bool
+}
+
+// init applies the defaults and sets the initial values.
+func (s *sliderTrack) init(r image.Rectangle, style sliderStyle, values ...float64) {
+	if style.max <= style.min {
+		style.max = style.min + 1
+	}
+	if style.trackWidth <= 0 {
+		style.trackWidth = DefaultSliderTrackWidth
+	}
+	if style.handleRadius <= 0 {
+		style.handleRadius = DefaultSliderHandleRadius
+	}
+	if style.format == "" {
+		style.format = "%g"
+	}
+	s.r = r
+	s.style = style
+	s.values = make([]float64, len(values))
+	for i := 0; i < len(values); i++ {
+		s.values[i] = s.snap(values[i])
+	}
+	for i := 1; i < len(s.values); i++ {
+		if s.values[i] < s.values[i-1] {
+			s.values[i] = s.values[i-1]
+		}
+	}
+	s.label = DynamicText(style.text)
+	s.handle = DynamicCircle(&CircleOptions{})
+}
+
+// Disabled returns true if the slider ignores input.
+func (s *sliderTrack) Disabled() bool {
+	return s.style.disabled
+}
+
+// SetDisabled enables or disables the slider.
+func (s *sliderTrack) SetDisabled(disabled bool) {
+	s.style.disabled = disabled
+	if disabled {
+		s.dragging = false
+	}
+}
+
+// Focused returns true if the slider has keyboard focus.
+func (s *sliderTrack) Focused() bool {
+	return s.focused
+}
+
+// OnFocus highlights the active handle.
+func (s *sliderTrack) OnFocus() {
+	s.focused = true
+}
+
+// OnBlur removes the highlight.
+func (s *sliderTrack) OnBlur() {
+	s.focused = false
+}
+
+// Contains returns true if the point is on the track or a handle.
+func (s *sliderTrack) Contains(x, y int) bool {
+	return image.Pt(x, y).In(s.r.Inset(-int(math.Ceil(s.style.handleRadius))))
+}
+
+// OnMouseEvent jumps the nearest handle to a press on the track and starts dragging it.
+func (s *sliderTrack) OnMouseEvent(x, y int, evt MouseEvent) {
+	if s.style.disabled || evt.Button != ebiten.MouseButtonLeft {
+		return
+	}
+	switch evt.EventType {
+	case MousePressEvent:
+		if !s.Contains(x, y) {
+			return
+		}
+		v := s.valueAt(x, y)
+		s.active = s.nearest(v)
+		s.dragging = true
+		s.set(s.active, v)
+	case MouseReleaseEvent:
+		s.dragging = false
+	}
+}
+
+// OnMouseMove drags the handle.
+func (s *sliderTrack) OnMouseMove(x, y int) {
+	s.hover = s.Contains(x, y)
+	if s.dragging {
+		s.set(s.active, s.valueAt(x, y))
+	}
+}
+
+// OnKeyEvent moves the active handle by a step with the arrow keys, by ten steps with Page Up and Page Down and to
+// either end with Home and End.
+func (s *sliderTrack) OnKeyEvent(evt KeyEvent) {
+	if s.style.disabled || evt.EventType == KeyReleaseEvent {
+		return
+	}
+	step := s.style.step
+	if step <= 0 {
+		step = (s.style.max - s.style.min) / 100
+	}
+	v := s.values[s.active]
+	switch evt.Key {
+	case ebiten.KeyRight, ebiten.KeyUp:
+		v += step
+	case ebiten.KeyLeft, ebiten.KeyDown:
+		v -= step
+	case ebiten.KeyPageUp:
+		v += 10 * step
+	case ebiten.KeyPageDown:
+		v -= 10 * step
+	case ebiten.KeyHome:
+		v = s.style.min
+	case ebiten.KeyEnd:
+		v = s.style.max
+	default:
+		return
+	}
+	s.set(s.active, v)
+}
+
+// Update updates the value label.
+func (s *sliderTrack) Update(ctx *UpdateContext) error {
+	if !s.style.showValue {
+		return nil
+	}
+	if s.style.disabled {
+		s.label.SetTextColor(ThemeFromContext(ctx.Context()).Widget.Disabled.Foreground)
+	} else {
+		s.label.SetTextColor(s.style.text.TextColor)
+	}
+	s.label.SetText(s.valueText())
+	return s.label.Update(ctx)
+}
+
+// Display renders the track, the filled range, the handles and the value label.
+func (s *sliderTrack) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	track, fill, handle := s.colors(theme)
+
+	// track
+	x0, y0 := s.position(s.style.min)
+	x1, y1 := s.position(s.style.max)
+	s.drawBar(ctx, x0, y0, x1, y1, track)
+
+	// the filled range runs from the minimum to a single handle or between two handles
+	from, to := s.style.min, s.values[0]
+	if len(s.values) > 1 {
+		from, to = s.values[0], s.values[len(s.values)-1]
+	}
+	x0, y0 = s.position(from)
+	x1, y1 = s.position(to)
+	s.drawBar(ctx, x0, y0, x1, y1, fill)
+
+	// handles
+	for i := 0; i < len(s.values); i++ {
+		state := theme.Widget.Normal
+		switch {
+		case s.style.disabled:
+			state = theme.Widget.Disabled
+		case s.focused && i == s.active:
+			state = theme.Widget.Focused
+		case s.hover || s.dragging:
+			state = theme.Widget.Hover
+		}
+		x, y := s.position(s.values[i])
+		s.handle.SetPosition(x, y)
+		s.handle.SetFillColor(state.Border)
+		s.handle.SetRadius(s.style.handleRadius)
+		s.handle.Display(ctx)
+		if handle == nil {
+			s.handle.SetFillColor(state.Background)
+		} else {
+			s.handle.SetFillColor(handle)
+		}
+		s.handle.SetRadius(s.style.handleRadius - float64(theme.BorderWidth))
+		s.handle.Display(ctx)
+	}
+
+	// value label
+	if s.style.showValue {
+		b := s.label.TextBounds()
+		gap := int(s.style.handleRadius) + theme.Spacing.S
+		if s.style.vertical {
+			s.label.SetPosition(float64(s.r.Min.X+(s.r.Dx()-b.Dx())/2), float64(s.r.Max.Y+gap))
+		} else {
+			s.label.SetPosition(float64(s.r.Max.X+gap), float64(s.r.Min.Y+(s.r.Dy()-b.Dy())/2))
+		}
+		s.label.Display(ctx)
+	}
+}
+
+// Measure returns the size of the track including the handles.
+func (s *sliderTrack) Measure(cons Constraints) SizeHints {
+	return BoundsSizeHints(s.r.Inset(-int(math.Ceil(s.style.handleRadius))))
+}
+
+// colors returns the track, fill and handle colors. A nil handle color uses the widget colors of the theme.
+func (s *sliderTrack) colors(t *Theme) (color.Color, color.Color, color.Color) {
+	track, fill, handle := s.style.trackColor, s.style.fillColor, s.style.handleColor
+	if track == nil {
+		track = t.Widget.Normal.Border
+	}
+	if fill == nil {
+		fill = t.Palette.Primary
+	}
+	if s.style.disabled {
+		track, fill, handle = t.Widget.Disabled.Border, t.Widget.Disabled.Foreground, nil
+	}
+	return track, fill, handle
+}
+
+// drawBar draws a bar of the track width between the points.
+func (s *sliderTrack) drawBar(ctx *DisplayContext, x0, y0, x1, y1 float64, c color.Color) {
+	half := s.style.trackWidth / 2
+	var vs []ebiten.Vertex
+	var is []uint16
+	if s.style.vertical {
+		vs, is = RectVertices(int(x0)-half, int(math.Min(y0, y1)), int(x0)-half+s.style.trackWidth, int(math.Max(y0, y1)), c)
+	} else {
+		vs, is = RectVertices(int(math.Min(x0, x1)), int(y0)-half, int(math.Max(x0, x1)), int(y0)-half+s.style.trackWidth, c)
+	}
+	ctx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+}
+
+// set moves the handle to the value, keeps the handles in order and calls the change callback if the value changed.
+func (s *sliderTrack) set(i int, v float64) {
+	v = s.snap(v)
+	if i > 0 && v < s.values[i-1] {
+		v = s.values[i-1]
+	}
+	if i < len(s.values)-1 && v > s.values[i+1] {
+		v = s.values[i+1]
+	}
+	if v == s.values[i] {
+		return
+	}
+	s.values[i] = v
+	if s.changed != nil {
+		s.changed()
+	}
+}
+
+// snap clamps the value to the range and rounds it to the step.
+func (s *sliderTrack) snap(v float64) float64 {
+	if s.style.step > 0 {
+		v = s.style.min + math.Round((v-s.style.min)/s.style.step)*s.style.step
+	}
+	return math.Max(s.style.min, math.Min(s.style.max, v))
+}
+
+// nearest returns the index of the handle nearest to the value. Between equal handles the one which can move toward
+// the value is chosen.
+func (s *sliderTrack) nearest(v float64) int {
+	best := 0
+	for i := 1; i < len(s.values); i++ {
+		d, bd := math.Abs(s.values[i]-v), math.Abs(s.values[best]-v)
+		if d < bd || (d == bd && v > s.values[i]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// valueAt returns the value at the point on the track.
+func (s *sliderTrack) valueAt(x, y int) float64 {
+	var t float64
+	if s.style.vertical && s.r.Dy() > 0 {
+		t = float64(s.r.Max.Y-y) / float64(s.r.Dy())
+	} else if !s.style.vertical && s.r.Dx() > 0 {
+		t = float64(x-s.r.Min.X) / float64(s.r.Dx())
+	}
+	return s.style.min + t*(s.style.max-s.style.min)
+}
+
+// position returns the point on the track for the value.
+func (s *sliderTrack) position(v float64) (float64, float64) {
+	t := (v - s.style.min) / (s.style.max - s.style.min)
+	if s.style.vertical {
+		return float64(s.r.Min.X) + float64(s.r.Dx())/2, float64(s.r.Max.Y) - t*float64(s.r.Dy())
+	}
+	return float64(s.r.Min.X) + t*float64(s.r.Dx()), float64(s.r.Min.Y) + float64(s.r.Dy())/2
+}
+
+// valueText returns the value label.
+func (s *sliderTrack) valueText() string {
+	text := fmt.Sprintf(s.style.format, s.values[0])
+	for i := 1; i < len(s.values); i++ {
+		text += " - " + fmt.Sprintf(s.style.format, s.values[i])
+	}
+	return text
+}
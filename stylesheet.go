@@ -0,0 +1,567 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Style stores the properties set by the rules of a style sheet. Unset properties are nil or zero and leave the
+// options of a component unchanged.
+type Style struct {
+	FillColor       color.Color
+	TextColor       color.Color
+	BackgroundColor color.Color
+	Border          *Border
+	Font            string
+	FontSize        float64
+	Padding         *Quad
+	Margin          *Quad
+}
+
+// merge returns the style with the set properties of the other style applied on top.
+func (s Style) merge(o Style) Style {
+	if o.FillColor != nil {
+		s.FillColor = o.FillColor
+	}
+	if o.TextColor != nil {
+		s.TextColor = o.TextColor
+	}
+	if o.BackgroundColor != nil {
+		s.BackgroundColor = o.BackgroundColor
+	}
+	if o.Border != nil {
+		s.Border = o.Border
+	}
+	if o.Font != "" {
+		s.Font = o.Font
+	}
+	if o.FontSize != 0 {
+		s.FontSize = o.FontSize
+	}
+	if o.Padding != nil {
+		s.Padding = o.Padding
+	}
+	if o.Margin != nil {
+		s.Margin = o.Margin
+	}
+	return s
+}
+
+// Text returns a copy of the text options with the style applied.
+func (s Style) Text(opts *TextOptions) *TextOptions {
+	var o TextOptions
+	if opts != nil {
+		o = *opts
+	}
+	if s.TextColor != nil {
+		o.TextColor = s.TextColor
+	}
+	if s.BackgroundColor != nil {
+		o.BackgroundColor = s.BackgroundColor
+	}
+	if s.Font != "" {
+		o.Font = s.Font
+	}
+	if s.FontSize != 0 {
+		o.FontSize = s.FontSize
+	}
+	if s.Padding != nil {
+		o.Padding = *s.Padding
+	}
+	return &o
+}
+
+// Rectangle returns a copy of the rectangle options with the style applied.
+func (s Style) Rectangle(opts *RectangleOptions) *RectangleOptions {
+	var o RectangleOptions
+	if opts != nil {
+		o = *opts
+	}
+	if s.FillColor != nil {
+		o.FillColor = s.FillColor
+	}
+	if s.Border != nil {
+		o.Border = *s.Border
+	}
+	if s.Margin != nil {
+		o.Margin = *s.Margin
+	}
+	return &o
+}
+
+// Container returns a copy of the container options with the style applied.
+func (s Style) Container(opts *ContainerOptions) *ContainerOptions {
+	var o ContainerOptions
+	if opts != nil {
+		o = *opts
+	}
+	if s.FillColor != nil {
+		o.FillColor = s.FillColor
+	}
+	if s.Border != nil {
+		o.Border = *s.Border
+	}
+	if s.Padding != nil {
+		o.Padding = *s.Padding
+	}
+	if s.Margin != nil {
+		o.Margin = *s.Margin
+	}
+	return &o
+}
+
+// NewStyleSheet creates an empty style sheet.
+func NewStyleSheet() *StyleSheet {
+	return &StyleSheet{rules: make(map[string]Style)}
+}
+
+// StyleSheet maps selectors to styles. A selector is a component type such as "Text", a class such as ".title" or
+// both such as "Text.title". Class selectors override type selectors and combined selectors override both.
+type StyleSheet struct {
+	rules map[string]Style
+}
+
+// Set sets the style of the selector.
+func (s *StyleSheet) Set(selector string, style Style) *StyleSheet {
+	s.rules[selector] = style
+	return s
+}
+
+// Selectors returns the selectors of the style sheet in sorted order.
+func (s *StyleSheet) Selectors() []string {
+	selectors := make([]string, 0, len(s.rules))
+	for sel := range s.rules {
+		selectors = append(selectors, sel)
+	}
+	sort.Strings(selectors)
+	return selectors
+}
+
+// Style returns the style of the component type with the classes, which are separated by spaces. Later classes
+// override earlier ones.
+func (s *StyleSheet) Style(typ, class string) Style {
+	var style Style
+	if s == nil {
+		return style
+	}
+	classes := strings.Fields(class)
+	if r, ok := s.rules[typ]; ok {
+		style = style.merge(r)
+	}
+	for i := 0; i < len(classes); i++ {
+		if r, ok := s.rules["."+classes[i]]; ok {
+			style = style.merge(r)
+		}
+	}
+	for i := 0; i < len(classes); i++ {
+		if r, ok := s.rules[typ+"."+classes[i]]; ok {
+			style = style.merge(r)
+		}
+	}
+	return style
+}
+
+// Style returns the style of the component type with the classes from the style sheet of the theme.
+func (t *Theme) Style(typ, class string) Style {
+	return t.Styles.Style(typ, class)
+}
+
+// Styled creates a component built from the style of the component type and classes. It is rebuilt whenever the theme
+// of the display changes, including when a watched theme file is reloaded.
+func Styled(typ, class string, build func(s Style) Component) Component {
+	return Themed(func(t *Theme) Component {
+		return build(t.Style(typ, class))
+	})
+}
+
+// StyledText creates a text component styled by the "Text" rules for the classes.
+func StyledText(class, msg string, x, y int, opts *TextOptions) Component {
+	return Styled("Text", class, func(s Style) Component {
+		return Text(msg, x, y, s.Text(opts))
+	})
+}
+
+// StyledDynamicText creates a dynamic text component styled by the "Text" rules for the classes. The style follows
+// the theme of the display.
+func StyledDynamicText(class string, opts *TextOptions) *DynamicTextComponent {
+	d := DynamicText(opts)
+	d.class = class
+	d.applyTheme(DefaultTheme)
+	return d
+}
+
+// StyledRectangle creates a rectangle styled by the "Rectangle" rules for the classes.
+func StyledRectangle(class string, r image.Rectangle, opts *RectangleOptions) Component {
+	return Styled("Rectangle", class, func(s Style) Component {
+		return Rectangle(r, s.Rectangle(opts))
+	})
+}
+
+// StyledContainer creates a container styled by the "Container" rules for the classes.
+func StyledContainer(class string, r image.Rectangle, opts *ContainerOptions, children ...Component) Component {
+	return Styled("Container", class, func(s Style) Component {
+		return Container(r, s.Container(opts), children...)
+	})
+}
+
+// ParseHexColor parses a color in the form #rgb, #rgba, #rrggbb or #rrggbbaa.
+func ParseHexColor(s string) (color.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 || len(hex) == 4 {
+		var b strings.Builder
+		for i := 0; i < len(hex); i++ {
+			b.WriteByte(hex[i])
+			b.WriteByte(hex[i])
+		}
+		hex = b.String()
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	var c color.NRGBA
+	if len(hex) != 8 {
+		return nil, fmt.Errorf("invalid color: %q", s)
+	}
+	if _, err := fmt.Sscanf(hex, "%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A); err != nil {
+		return nil, fmt.Errorf("invalid color: %q", s)
+	}
+	return c, nil
+}
+
+// LoadTheme reads a JSON theme file. The file starts from the "light" or "dark" base theme, overrides its palette,
+// typography, spacing and widget colors and defines the style rules. Colors are hex strings and padding or margin is
+// one, two or four numbers in the order of CSS.
+//
+//	{
+//	  "theme": {"base": "dark", "palette": {"primary": "#ff9800"}, "typography": {"body": 14}},
+//	  "styles": {
+//	    "Container.panel": {"fill": "#2d2d2d", "border": {"color": "#555", "width": 1}, "padding": [8]},
+//	    "Text.title": {"fontSize": 24, "textColor": "#fff"}
+//	  }
+//	}
+func LoadTheme(r io.Reader) (*Theme, error) {
+	var f themeFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	return f.build()
+}
+
+// LoadThemeFile reads a JSON theme file from the path.
+func LoadThemeFile(path string) (*Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := LoadTheme(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return t, nil
+}
+
+// themeFile is the JSON representation of a theme file.
+type themeFile struct {
+	Theme  themeJSON            `json:"theme"`
+	Styles map[string]styleJSON `json:"styles"`
+}
+
+type themeJSON struct {
+	Base        string           `json:"base"`
+	Name        string           `json:"name"`
+	Palette     paletteJSON      `json:"palette"`
+	Typography  typographyJSON   `json:"typography"`
+	Spacing     spacingJSON      `json:"spacing"`
+	BorderWidth *int             `json:"borderWidth"`
+	Radius      *int             `json:"radius"`
+	Widget      widgetStylesJSON `json:"widget"`
+}
+
+type paletteJSON struct {
+	Background *jsonColor `json:"background"`
+	Surface    *jsonColor `json:"surface"`
+	Primary    *jsonColor `json:"primary"`
+	OnPrimary  *jsonColor `json:"onPrimary"`
+	Text       *jsonColor `json:"text"`
+	TextMuted  *jsonColor `json:"textMuted"`
+	Border     *jsonColor `json:"border"`
+	Selection  *jsonColor `json:"selection"`
+	Error      *jsonColor `json:"error"`
+}
+
+type typographyJSON struct {
+	Font    string  `json:"font"`
+	Caption float64 `json:"caption"`
+	Body    float64 `json:"body"`
+	Title   float64 `json:"title"`
+	Heading float64 `json:"heading"`
+}
+
+type spacingJSON struct {
+	XS *int `json:"xs"`
+	S  *int `json:"s"`
+	M  *int `json:"m"`
+	L  *int `json:"l"`
+	XL *int `json:"xl"`
+}
+
+type stateStyleJSON struct {
+	Background *jsonColor `json:"background"`
+	Foreground *jsonColor `json:"foreground"`
+	Border     *jsonColor `json:"border"`
+}
+
+type widgetStylesJSON struct {
+	Normal   stateStyleJSON `json:"normal"`
+	Hover    stateStyleJSON `json:"hover"`
+	Pressed  stateStyleJSON `json:"pressed"`
+	Focused  stateStyleJSON `json:"focused"`
+	Disabled stateStyleJSON `json:"disabled"`
+}
+
+type styleJSON struct {
+	Fill       *jsonColor  `json:"fill"`
+	TextColor  *jsonColor  `json:"textColor"`
+	Background *jsonColor  `json:"background"`
+	Border     *borderJSON `json:"border"`
+	Font       string      `json:"font"`
+	FontSize   float64     `json:"fontSize"`
+	Padding    *jsonQuad   `json:"padding"`
+	Margin     *jsonQuad   `json:"margin"`
+}
+
+type borderJSON struct {
+	Color *jsonColor `json:"color"`
+	Width int        `json:"width"`
+}
+
+// build creates the theme described by the file.
+func (f *themeFile) build() (*Theme, error) {
+	var t *Theme
+	switch f.Theme.Base {
+	case "", "light":
+		t = LightTheme()
+	case "dark":
+		t = DarkTheme()
+	default:
+		return nil, fmt.Errorf("unknown base theme: %q", f.Theme.Base)
+	}
+	if f.Theme.Name != "" {
+		t.Name = f.Theme.Name
+	}
+
+	p := f.Theme.Palette
+	setColor(&t.Palette.Background, p.Background)
+	setColor(&t.Palette.Surface, p.Surface)
+	setColor(&t.Palette.Primary, p.Primary)
+	setColor(&t.Palette.OnPrimary, p.OnPrimary)
+	setColor(&t.Palette.Text, p.Text)
+	setColor(&t.Palette.TextMuted, p.TextMuted)
+	setColor(&t.Palette.Border, p.Border)
+	setColor(&t.Palette.Selection, p.Selection)
+	setColor(&t.Palette.Error, p.Error)
+
+	ty := f.Theme.Typography
+	if ty.Font != "" {
+		t.Typography.Font = ty.Font
+	}
+	setFloat(&t.Typography.Caption, ty.Caption)
+	setFloat(&t.Typography.Body, ty.Body)
+	setFloat(&t.Typography.Title, ty.Title)
+	setFloat(&t.Typography.Heading, ty.Heading)
+
+	sp := f.Theme.Spacing
+	setInt(&t.Spacing.XS, sp.XS)
+	setInt(&t.Spacing.S, sp.S)
+	setInt(&t.Spacing.M, sp.M)
+	setInt(&t.Spacing.L, sp.L)
+	setInt(&t.Spacing.XL, sp.XL)
+	setInt(&t.BorderWidth, f.Theme.BorderWidth)
+	setInt(&t.Radius, f.Theme.Radius)
+
+	w := f.Theme.Widget
+	w.Normal.apply(&t.Widget.Normal)
+	w.Hover.apply(&t.Widget.Hover)
+	w.Pressed.apply(&t.Widget.Pressed)
+	w.Focused.apply(&t.Widget.Focused)
+	w.Disabled.apply(&t.Widget.Disabled)
+
+	t.Styles = NewStyleSheet()
+	for sel, s := range f.Styles {
+		if sel == "" || strings.Count(sel, ".") > 1 {
+			return nil, fmt.Errorf("invalid selector: %q", sel)
+		}
+		t.Styles.Set(sel, s.style(t))
+	}
+	return t, nil
+}
+
+// apply sets the colors of the state style.
+func (s stateStyleJSON) apply(dst *StateStyle) {
+	setColor(&dst.Background, s.Background)
+	setColor(&dst.Foreground, s.Foreground)
+	setColor(&dst.Border, s.Border)
+}
+
+// style converts the rule to a style. A border without a color uses the border color of the theme.
+func (s styleJSON) style(t *Theme) Style {
+	style := Style{
+		Font:     s.Font,
+		FontSize: s.FontSize,
+	}
+	setColor(&style.FillColor, s.Fill)
+	setColor(&style.TextColor, s.TextColor)
+	setColor(&style.BackgroundColor, s.Background)
+	if s.Border != nil {
+		c := t.Palette.Border
+		setColor(&c, s.Border.Color)
+		b := StrokeBorder(c, s.Border.Width)
+		style.Border = &b
+	}
+	if s.Padding != nil {
+		q := Quad(*s.Padding)
+		style.Padding = &q
+	}
+	if s.Margin != nil {
+		q := Quad(*s.Margin)
+		style.Margin = &q
+	}
+	return style
+}
+
+func setColor(dst *color.Color, c *jsonColor) {
+	if c != nil {
+		*dst = c.Color
+	}
+}
+
+func setFloat(dst *float64, v float64) {
+	if v != 0 {
+		*dst = v
+	}
+}
+
+func setInt(dst *int, v *int) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// jsonColor is a color decoded from a hex string.
+type jsonColor struct {
+	color.Color
+}
+
+// UnmarshalJSON parses the hex string.
+func (c *jsonColor) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := ParseHexColor(s)
+	if err != nil {
+		return err
+	}
+	c.Color = v
+	return nil
+}
+
+// jsonQuad is a quad decoded from one, two or four numbers in the order of CSS: all sides, vertical and horizontal
+// or top, right, bottom and left.
+type jsonQuad Quad
+
+// UnmarshalJSON parses the number or array.
+func (q *jsonQuad) UnmarshalJSON(data []byte) error {
+	var v []int
+	if err := json.Unmarshal(data, &v); err != nil {
+		var n int
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("invalid quad: %s", data)
+		}
+		v = []int{n}
+	}
+	switch len(v) {
+	case 1:
+		*q = jsonQuad(UniformQuad(v[0]))
+	case 2:
+		*q = jsonQuad{Top: v[0], Bottom: v[0], Left: v[1], Right: v[1]}
+	case 4:
+		*q = jsonQuad{Top: v[0], Right: v[1], Bottom: v[2], Left: v[3]}
+	default:
+		return fmt.Errorf("invalid quad: %s", data)
+	}
+	return nil
+}
+
+// ThemeWatchInterval is the time between checks of a watched theme file.
+const ThemeWatchInterval = 500 * time.Millisecond
+
+// WatchTheme loads the theme file, sets it as the theme of the display and reloads it whenever the file is modified.
+// A file which fails to load is logged and the current theme is kept until the file is fixed.
+func (d *Display) WatchTheme(path string) (*ThemeWatcher, error) {
+	w := &ThemeWatcher{display: d, path: path}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+	d.AddUpdateHandler(w)
+	return w, nil
+}
+
+// ThemeWatcher reloads a theme file when it changes.
+type ThemeWatcher struct {
+	display *Display
+	path    string
+	modTime time.Time
+	checked time.Time
+	stopped bool
+}
+
+// Reload loads the theme file and sets it as the theme of the display.
+func (w *ThemeWatcher) Reload() error {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	t, err := LoadThemeFile(w.path)
+	if err != nil {
+		return err
+	}
+	w.modTime = info.ModTime()
+	w.display.SetTheme(t)
+	return nil
+}
+
+// Stop stops watching the file.
+func (w *ThemeWatcher) Stop() {
+	w.stopped = true
+}
+
+// Update checks the modification time of the file and reloads it if it changed. The wall clock is used so that
+// files are still reloaded while the game clock is paused.
+func (w *ThemeWatcher) Update(ctx *UpdateContext) error {
+	now := time.Now()
+	if w.stopped || now.Sub(w.checked) < ThemeWatchInterval {
+		return nil
+	}
+	w.checked = now
+
+	info, err := os.Stat(w.path)
+	if err != nil || info.ModTime().Equal(w.modTime) {
+		return nil
+	}
+	if err := w.Reload(); err != nil {
+		w.modTime = info.ModTime()
+		log.Printf("failed to reload theme: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+)
+
+// switchSlideSpeed is the fraction of the track the knob slides per second.
+const switchSlideSpeed = 8
+
+// SwitchOptions contains the options for a switch. Unset fonts and colors follow the theme and a zero size or gap uses
+// the defaults. The track is twice as wide as the size.
+type SwitchOptions struct {
+	Label     string
+	Font      string
+	FontSize  float64
+	TextColor color.Color
+	Size      int
+	Gap       int
+	On        bool
+	Disabled  bool
+
+	// OnChange is called whenever the switch is toggled by the user.
+	OnChange func(on bool)
+}
+
+// Switch creates a themed on/off switch with a label at the position. It is toggled by clicking the track or the label
+// and by pressing Space or Enter while focused. The knob slides to its new side with the game clock.
+func Switch(x, y int, opts *SwitchOptions) *SwitchComponent {
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultToggleSize
+	}
+	s := &SwitchComponent{
+		opts: opts,
+		on:   opts.On,
+		knob: DynamicCircle(&CircleOptions{}),
+		ends: DynamicCircle(&CircleOptions{}),
+	}
+	if s.on {
+		s.pos = 1
+	}
+	s.init(x, y, 2*size, size, opts.Gap, opts.Disabled, &TextOptions{
+		Font:      opts.Font,
+		FontSize:  opts.FontSize,
+		TextColor: opts.TextColor,
+	}, opts.Label)
+	return s
+}
+
+// SwitchComponent is an on/off switch with a label.
+type SwitchComponent struct {
+	toggleControl
+	opts *SwitchOptions
+	on   bool
+	pos  float64
+	knob *DynamicCircleComponent
+	ends *DynamicCircleComponent
+}
+
+// On returns true if the switch is on.
+func (s *SwitchComponent) On() bool {
+	return s.on
+}
+
+// SetOn turns the switch on or off without sliding the knob. OnChange is not called.
+func (s *SwitchComponent) SetOn(on bool) *SwitchComponent {
+	s.on = on
+	s.pos = 0
+	if on {
+		s.pos = 1
+	}
+	return s
+}
+
+// Toggle toggles the switch and calls OnChange.
+func (s *SwitchComponent) Toggle() {
+	s.on = !s.on
+	if s.opts.OnChange != nil {
+		s.opts.OnChange(s.on)
+	}
+}
+
+// OnMouseEvent toggles the switch when it is clicked.
+func (s *SwitchComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if s.click(x, y, evt) {
+		s.Toggle()
+	}
+}
+
+// OnKeyEvent toggles the switch when Space or Enter is pressed.
+func (s *SwitchComponent) OnKeyEvent(evt KeyEvent) {
+	if s.activated(evt) {
+		s.Toggle()
+	}
+}
+
+// Update slides the knob and updates the label.
+func (s *SwitchComponent) Update(ctx *UpdateContext) error {
+	step := ctx.Delta().Seconds() * switchSlideSpeed
+	if s.on {
+		s.pos += step
+		if s.pos > 1 {
+			s.pos = 1
+		}
+	} else {
+		s.pos -= step
+		if s.pos < 0 {
+			s.pos = 0
+		}
+	}
+	return s.toggleControl.Update(ctx)
+}
+
+// Display renders the track, the knob and the label.
+func (s *SwitchComponent) Display(ctx *DisplayContext) {
+	theme := ThemeFromContext(ctx.Context())
+	state := s.state(theme)
+	track := s.control()
+
+	// the focus ring is drawn as a larger track behind the track
+	if s.focused && !s.disabled {
+		s.drawTrack(ctx, track.Inset(-2), theme.Widget.Focused.Border)
+	}
+
+	fill, knob := state.Border, state.Background
+	if s.on {
+		fill, knob = theme.Palette.Primary, theme.Palette.OnPrimary
+		if s.disabled {
+			fill, knob = theme.Widget.Disabled.Foreground, theme.Widget.Disabled.Background
+		}
+	}
+	s.drawTrack(ctx, track, fill)
+
+	// knob
+	r := float64(track.Dy()) / 2
+	x := float64(track.Min.X) + r + s.pos*(float64(track.Dx())-2*r)
+	s.knob.SetFillColor(knob)
+	s.knob.SetRadius(r - float64(theme.BorderWidth) - 1)
+	s.knob.SetPosition(x, float64(track.Min.Y)+r)
+	s.knob.Display(ctx)
+
+	s.displayLabel(ctx)
+}
+
+// drawTrack fills the rectangle with round ends.
+func (s *SwitchComponent) drawTrack(ctx *DisplayContext, track image.Rectangle, fill color.Color) {
+	r := track.Dy() / 2
+	cy := float64(track.Min.Y) + float64(track.Dy())/2
+	s.ends.SetFillColor(fill)
+	s.ends.SetRadius(float64(track.Dy()) / 2)
+	s.ends.SetPosition(float64(track.Min.X+r), cy)
+	s.ends.Display(ctx)
+	s.ends.SetPosition(float64(track.Max.X-r), cy)
+	s.ends.Display(ctx)
+	drawBox(ctx, image.Rect(track.Min.X+r, track.Min.Y, track.Max.X-r, track.Max.Y), fill, nil, 0)
+}
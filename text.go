@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"image"
 	"image/color"
 	"log"
 	"strings"
@@ -10,24 +11,50 @@ import (
 	"golang.org/x/image/font"
 )
 
-// TextOptions contains the options for a text component.
+// TextOptions contains the options for a text component. An empty font, zero font size or nil text color is taken
+// from the theme, with the size chosen by the style.
 type TextOptions struct {
 	Font             string
 	FontSize         float64
+	Style            TextStyle
 	TextColor        color.Color
 	BackgroundColor  color.Color
 	Padding          Quad
 	CenterX, CenterY bool
 }
 
-// Text creates a new component for rentering text.
-func Text(msg string, x, y int, opts *TextOptions) Component {
-	if opts.TextColor == nil {
-		opts.TextColor = color.Black
+// themed returns true if any of the options are taken from the theme.
+func (opts *TextOptions) themed() bool {
+	return opts.Font == "" || opts.FontSize == 0 || opts.TextColor == nil
+}
+
+// resolve returns a copy of the options with the unset options taken from the theme.
+func (opts *TextOptions) resolve(t *Theme) *TextOptions {
+	o := *opts
+	if o.Font == "" {
+		o.Font = t.Typography.Font
 	}
-	if opts.FontSize == 0 {
-		opts.FontSize = 12
+	if o.FontSize == 0 {
+		o.FontSize = t.Typography.Size(o.Style)
+	}
+	if o.TextColor == nil {
+		o.TextColor = t.Palette.Text
+	}
+	return &o
+}
+
+// Text creates a new component for rentering text. Text with themed options is rendered again when the theme changes.
+func Text(msg string, x, y int, opts *TextOptions) Component {
+	if opts.themed() {
+		return Themed(func(t *Theme) Component {
+			return staticText(msg, x, y, opts.resolve(t))
+		})
 	}
+	return staticText(msg, x, y, opts)
+}
+
+// staticText renders the text to an image.
+func staticText(msg string, x, y int, opts *TextOptions) Component {
 
 	// load text
 	ff, err := NewFontFace(opts.Font, opts.FontSize)
@@ -60,37 +87,30 @@ func Text(msg string, x, y int, opts *TextOptions) Component {
 	})
 }
 
-// DynamicText craetes a dynamic text component.
+// DynamicText craetes a dynamic text component. Themed options follow the theme of the display.
 func DynamicText(opts *TextOptions) *DynamicTextComponent {
-	if opts.TextColor == nil {
-		opts.TextColor = color.Black
-	}
-	if opts.FontSize == 0 {
-		opts.FontSize = 12
-	}
-
-	// load text
-	ff, err := NewFontFace(opts.Font, opts.FontSize)
-	if err != nil {
-		log.Fatalf("failed to load font: %s err=%s", opts.Font, err)
-	}
-
-	return &DynamicTextComponent{
-		opts:     opts,
-		fontFace: ff,
-		tImage:   ebiten.NewImage(1, 1),
+	d := &DynamicTextComponent{
+		themeOpts: *opts,
+		theme:     DefaultTheme,
+		tImage:    ebiten.NewImage(1, 1),
 	}
+	d.applyTheme(DefaultTheme)
+	return d
 }
 
 // DynamicTextComponent is a text component that is optimized for dynamic text.
 type DynamicTextComponent struct {
-	opts     *TextOptions
-	fontFace font.Face
-	tImage   *ebiten.Image
+	opts      *TextOptions
+	themeOpts TextOptions
+	class     string
+	theme     *Theme
+	fontFace  font.Face
+	tImage    *ebiten.Image
 
-	dirty bool
-	text  string
-	x, y  float64
+	dirty  bool
+	text   string
+	bounds image.Rectangle
+	x, y   float64
 }
 
 // SetText updates the text.
@@ -102,6 +122,54 @@ func (d *DynamicTextComponent) SetText(s string) *DynamicTextComponent {
 	return d
 }
 
+// Text returns the text.
+func (d *DynamicTextComponent) Text() string {
+	return d.text
+}
+
+// FontFace returns the font face used to render the text.
+func (d *DynamicTextComponent) FontFace() font.Face {
+	return d.fontFace
+}
+
+// TextBounds returns the bounds of the rendered text relative to the text origin on the baseline. The bounds are
+// updated during Update.
+func (d *DynamicTextComponent) TextBounds() image.Rectangle {
+	return d.bounds
+}
+
+// SetTextColor updates the text color. A nil color follows the theme.
+func (d *DynamicTextComponent) SetTextColor(c color.Color) *DynamicTextComponent {
+	d.themeOpts.TextColor = c
+	if c == nil {
+		c = d.theme.Palette.Text
+	}
+	if c != d.opts.TextColor {
+		d.dirty = true
+		d.opts.TextColor = c
+	}
+	return d
+}
+
+// applyTheme resolves the options from the theme and its style rules for the class and reloads the font if it changed.
+func (d *DynamicTextComponent) applyTheme(t *Theme) {
+	d.theme = t
+	opts := &d.themeOpts
+	if d.class != "" {
+		opts = t.Style("Text", d.class).Text(opts)
+	}
+	opts = opts.resolve(t)
+	if d.opts == nil || opts.Font != d.opts.Font || opts.FontSize != d.opts.FontSize {
+		ff, err := NewFontFace(opts.Font, opts.FontSize)
+		if err != nil {
+			log.Fatalf("failed to load font: %s err=%s", opts.Font, err)
+		}
+		d.fontFace = ff
+	}
+	d.opts = opts
+	d.dirty = true
+}
+
 // SetPosition updates the position.
 func (d *DynamicTextComponent) SetPosition(x, y float64) *DynamicTextComponent {
 	d.x, d.y = x, y
@@ -110,16 +178,26 @@ func (d *DynamicTextComponent) SetPosition(x, y float64) *DynamicTextComponent {
 
 // Update updates the internal image.
 func (d *DynamicTextComponent) Update(ctx *UpdateContext) error {
+	if t := ThemeFromContext(ctx.Context()); t != d.theme && (d.themeOpts.themed() || d.class != "") {
+		d.applyTheme(t)
+	}
 	if d.dirty {
+		d.dirty = false
 
 		// text bounds
-		bounds := text.BoundString(d.fontFace, strings.ToUpper(d.text))
+		bounds := d.measureText()
+		d.bounds = bounds
 
-		// container image
-		d.tImage = ebiten.NewImage(
-			bounds.Dx()+d.opts.Padding.Left+d.opts.Padding.Right,
-			bounds.Dy()+d.opts.Padding.Top+d.opts.Padding.Bottom,
-		)
+		// container image, which cannot be empty
+		w := bounds.Dx() + d.opts.Padding.Left + d.opts.Padding.Right
+		h := bounds.Dy() + d.opts.Padding.Top + d.opts.Padding.Bottom
+		if w < 1 {
+			w = 1
+		}
+		if h < 1 {
+			h = 1
+		}
+		d.tImage = ebiten.NewImage(w, h)
 
 		// background color
 		if d.opts.BackgroundColor != nil {
@@ -155,3 +233,29 @@ func (d *DynamicTextComponent) Display(ctx *DisplayContext) {
 
 	ctx.DrawImage(d.tImage, op)
 }
+
+// Measure returns the size and position of the rendered text including padding.
+func (d *DynamicTextComponent) Measure(c Constraints) SizeHints {
+	bounds := d.measureText()
+	w := bounds.Dx() + d.opts.Padding.Left + d.opts.Padding.Right
+	h := bounds.Dy() + d.opts.Padding.Top + d.opts.Padding.Bottom
+	hints := FixedSizeHints(w, h)
+	hints.Origin = image.Pt(int(d.x), int(d.y))
+	if d.opts.CenterX {
+		hints.Origin.X -= w / 2
+	}
+	if d.opts.CenterY {
+		hints.Origin.Y -= h / 2
+	}
+	return hints
+}
+
+// measureText returns the bounds of the text relative to the text origin. The width is measured from the text itself
+// and the height spans the ascent and descent of the font, so descenders are not clipped and the baseline does not move
+// when the text changes.
+func (d *DynamicTextComponent) measureText() image.Rectangle {
+	bounds := text.BoundString(d.fontFace, d.text)
+	m := d.fontFace.Metrics()
+	bounds.Min.Y, bounds.Max.Y = -m.Ascent.Ceil(), m.Descent.Ceil()
+	return bounds
+}
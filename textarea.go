@@ -0,0 +1,537 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// TextAreaOptions contains the options for a text area.
+type TextAreaOptions struct {
+	Font            string
+	FontSize        float64
+	TextColor       color.Color
+	BackgroundColor color.Color
+	SelectionColor  color.Color
+	CaretColor      color.Color
+	Border          Border
+	Padding         Quad
+	MaxLength       int
+
+	// ReadOnly prevents editing while still allowing scrolling, selection and copy. Useful for log panels.
+	ReadOnly bool
+
+	// OnChange is called whenever the text is edited.
+	OnChange func(text string)
+}
+
+// TextArea creates a multi-line text component which wraps text to its width. Unset fonts, colors and border colors
+// follow the theme.
+func TextArea(r image.Rectangle, opts *TextAreaOptions) *TextAreaComponent {
+	// interior of the text area inside the border and padding
+	inner := image.Rect(
+		r.Min.X+opts.Border.Left.Width+opts.Padding.Left,
+		r.Min.Y+opts.Border.Top.Width+opts.Padding.Top,
+		r.Max.X-opts.Border.Right.Width-opts.Padding.Right,
+		r.Max.Y-opts.Border.Bottom.Width-opts.Padding.Bottom,
+	)
+	w, h := inner.Dx(), inner.Dy()
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	t := &TextAreaComponent{
+		r:     r,
+		inner: inner,
+		opts:  opts,
+		background: Rectangle(r, &RectangleOptions{
+			FillColor: opts.BackgroundColor,
+			Border:    opts.Border,
+		}),
+		bufferImage: ebiten.NewImage(w, h),
+		editor:      &textEditor{maxLength: opts.MaxLength, multiline: true},
+		lines:       []textLine{{0, 0}},
+		goalX:       -1,
+	}
+	t.applyTheme(DefaultTheme)
+	return t
+}
+
+// textLine is a visual line of wrapped text. The end index excludes any hard line break.
+type textLine struct {
+	start, end int
+}
+
+// TextAreaComponent is a multi-line text component with word wrap and vertical scrolling.
+type TextAreaComponent struct {
+	r           image.Rectangle
+	inner       image.Rectangle
+	opts        *TextAreaOptions
+	background  Component
+	theme       *Theme
+	fontFace    font.Face
+	fontName    string
+	fontSize    float64
+	bufferImage *ebiten.Image
+	editor      *textEditor
+
+	lines         []textLine
+	dirty         bool
+	focused       bool
+	dragging      bool
+	blink         int
+	scrollY       int
+	goalX         int
+	scrollToCaret bool
+}
+
+// Text returns the current text.
+func (t *TextAreaComponent) Text() string {
+	return t.editor.String()
+}
+
+// SetText replaces the text and moves the caret to the end. OnChange is not called.
+func (t *TextAreaComponent) SetText(s string) *TextAreaComponent {
+	t.editor.SetText(s)
+	t.dirty = true
+	return t
+}
+
+// AppendLine adds a line to the end of the text. With MaxLength set, the oldest lines are dropped to make room, which
+// suits log panels. If the text area was scrolled to the bottom, it remains scrolled to the bottom.
+func (t *TextAreaComponent) AppendLine(s string) *TextAreaComponent {
+	t.wrap()
+	follow := t.scrollY >= t.maxScroll()
+	if len(t.editor.text) > 0 {
+		s = "\n" + s
+	}
+	text := append(append([]rune(nil), t.editor.text...), []rune(s)...)
+
+	// drop whole lines from the start, or the start of the new line if it is longer than the maximum on its own
+	removed := 0
+	if max := t.editor.maxLength; max > 0 && len(text) > max {
+		removed = len(text) - max
+		for i := removed - 1; i < len(text); i++ {
+			if i >= 0 && text[i] == '\n' {
+				removed = i + 1
+				break
+			}
+		}
+		text = text[removed:]
+	}
+
+	// keep the view on the same lines when it does not follow the end
+	for i := 0; i < len(t.lines) && t.lines[i].start < removed; i++ {
+		t.scrollY -= t.lineHeight()
+	}
+
+	caret, anchor := t.editor.caret-removed, t.editor.anchor-removed
+	t.editor.text = text
+	if follow {
+		caret, anchor = len(text), len(text)
+	}
+	if caret < 0 {
+		caret = 0
+	}
+	if anchor < 0 {
+		anchor = 0
+	}
+	t.editor.caret, t.editor.anchor = caret, anchor
+	t.dirty = true
+	t.scrollToCaret = follow
+	return t
+}
+
+// Focused returns true if the text area has keyboard focus.
+func (t *TextAreaComponent) Focused() bool {
+	return t.focused
+}
+
+// OnFocus shows the caret.
+func (t *TextAreaComponent) OnFocus() {
+	t.focused = true
+	t.blink = 0
+}
+
+// OnBlur hides the caret.
+func (t *TextAreaComponent) OnBlur() {
+	t.focused = false
+	t.dragging = false
+}
+
+// Contains returns true if the point is within the text area.
+func (t *TextAreaComponent) Contains(x, y int) bool {
+	return image.Pt(x, y).In(t.r)
+}
+
+// OnKeyEvent edits the text and moves the caret between lines.
+func (t *TextAreaComponent) OnKeyEvent(evt KeyEvent) {
+	if evt.EventType == KeyReleaseEvent {
+		return
+	}
+	extend := evt.Modifiers.Has(ModShift)
+	line := t.lineAt(t.editor.caret)
+	visible := t.inner.Dy() / t.lineHeight()
+	if visible < 1 {
+		visible = 1
+	}
+
+	changed := false
+	switch evt.Key {
+	case ebiten.KeyUp:
+		t.moveLine(line-1, extend)
+	case ebiten.KeyDown:
+		t.moveLine(line+1, extend)
+	case ebiten.KeyPageUp:
+		t.moveLine(line-visible, extend)
+	case ebiten.KeyPageDown:
+		t.moveLine(line+visible, extend)
+	case ebiten.KeyHome:
+		t.editor.moveTo(t.lines[line].start, extend)
+		t.goalX = 0
+	case ebiten.KeyEnd:
+		end := t.lines[line].end
+
+		// stay on a soft wrapped line rather than moving to the start of the next
+		if line+1 < len(t.lines) && t.lines[line+1].start == end && end > t.lines[line].start {
+			end--
+		}
+		t.editor.moveTo(end, extend)
+		t.goalX = t.advance(t.lines[line].start, end)
+	case ebiten.KeyEnter, ebiten.KeyKPEnter:
+		if !t.opts.ReadOnly {
+			changed = t.editor.insert([]rune{'\n'})
+		}
+	default:
+		var handled bool
+		changed, handled = t.editor.handleKey(evt, t.opts.ReadOnly)
+		if !handled {
+			return
+		}
+		t.goalX = -1
+	}
+
+	t.blink = 0
+	t.scrollToCaret = true
+	if changed {
+		t.changed()
+	}
+}
+
+// OnTextInput inserts the typed characters at the caret.
+func (t *TextAreaComponent) OnTextInput(chars []rune) {
+	if t.opts.ReadOnly {
+		return
+	}
+	t.blink = 0
+	t.goalX = -1
+	t.scrollToCaret = true
+	if t.editor.insert(chars) {
+		t.changed()
+	}
+}
+
+// OnMouseEvent moves the caret to the mouse position and starts selecting. Double clicks select a word, triple clicks
+// select the line and the wheel scrolls the text.
+func (t *TextAreaComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if evt.EventType == MouseWheelEvent {
+		if t.Contains(x, y) {
+			t.scrollY -= int(evt.WheelY * float64(t.lineHeight()*3))
+		}
+		return
+	}
+	if evt.Button != ebiten.MouseButtonLeft {
+		return
+	}
+
+	switch evt.EventType {
+	case MousePressEvent:
+		if !t.Contains(x, y) {
+			return
+		}
+		t.dragging = true
+		t.blink = 0
+		t.goalX = -1
+		t.editor.moveTo(t.indexAt(x, y), false)
+	case MouseDoubleClickEvent:
+		if t.Contains(x, y) {
+			t.dragging = false
+			t.editor.selectWord(t.indexAt(x, y))
+		}
+	case MouseTripleClickEvent:
+		if t.Contains(x, y) {
+			t.dragging = false
+			pos := t.indexAt(x, y)
+			t.editor.anchor, t.editor.caret = t.editor.lineStart(pos), t.editor.lineEnd(pos)
+		}
+	case MouseReleaseEvent:
+		t.dragging = false
+	}
+}
+
+// OnMouseMove extends the selection while dragging.
+func (t *TextAreaComponent) OnMouseMove(x, y int) {
+	if t.dragging {
+		t.editor.moveTo(t.indexAt(x, y), true)
+	}
+}
+
+// Update wraps the text and scrolls the view.
+func (t *TextAreaComponent) Update(ctx *UpdateContext) error {
+	t.blink++
+	if err := t.background.Update(ctx); err != nil {
+		return err
+	}
+	if theme := ThemeFromContext(ctx.Context()); theme != t.theme {
+		t.applyTheme(theme)
+	}
+	t.wrap()
+
+	// keep the caret visible
+	if t.scrollToCaret {
+		t.scrollToCaret = false
+		top := t.lineAt(t.editor.caret) * t.lineHeight()
+		if top < t.scrollY {
+			t.scrollY = top
+		} else if top+t.lineHeight() > t.scrollY+t.inner.Dy() {
+			t.scrollY = top + t.lineHeight() - t.inner.Dy()
+		}
+	}
+
+	if max := t.maxScroll(); t.scrollY > max {
+		t.scrollY = max
+	}
+	if t.scrollY < 0 {
+		t.scrollY = 0
+	}
+	return nil
+}
+
+// Display renders the visible lines of the text area.
+func (t *TextAreaComponent) Display(ctx *DisplayContext) {
+	t.background.Display(ctx)
+
+	t.bufferImage.Fill(color.Transparent)
+	bufferCtx := NewDisplayContext(ctx.Context(), t.bufferImage)
+
+	m := t.fontFace.Metrics()
+	lineHeight := t.lineHeight()
+	textColor, selectionColor, caretColor := editorColors(ctx, t.opts.TextColor, t.opts.SelectionColor, t.opts.CaretColor)
+	_, h := t.bufferImage.Size()
+	selStart, selEnd := t.editor.selection()
+
+	first := t.scrollY / lineHeight
+	for i := first; i < len(t.lines) && i*lineHeight-t.scrollY < h; i++ {
+		line := t.lines[i]
+		top := i*lineHeight - t.scrollY
+
+		// selection
+		if selStart < selEnd && selStart <= line.end && selEnd >= line.start {
+			start, end := line.start, line.end
+			if selStart > start {
+				start = selStart
+			}
+			if selEnd < end {
+				end = selEnd
+			}
+			x0, x1 := t.advance(line.start, start), t.advance(line.start, end)
+
+			// show selected line breaks
+			if selEnd > line.end && line.end < len(t.editor.text) && t.editor.text[line.end] == '\n' {
+				x1 += lineHeight / 4
+			}
+			vs, is := RectVertices(x0, top, x1, top+lineHeight, selectionColor)
+			bufferCtx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+		}
+
+		text.Draw(t.bufferImage, string(t.editor.text[line.start:line.end]), t.fontFace, 0, top+m.Ascent.Ceil(), textColor)
+	}
+
+	// caret
+	if t.focused && !t.opts.ReadOnly && (t.blink/caretBlinkTicks)%2 == 0 {
+		i := t.lineAt(t.editor.caret)
+		x := t.advance(t.lines[i].start, t.editor.caret)
+		top := i*lineHeight - t.scrollY
+		vs, is := RectVertices(x, top, x+1, top+lineHeight, caretColor)
+		bufferCtx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(t.inner.Min.X), float64(t.inner.Min.Y))
+	ctx.DrawImage(t.bufferImage, op)
+}
+
+// lineHeight returns the height of a line in pixels.
+func (t *TextAreaComponent) lineHeight() int {
+	if h := t.fontFace.Metrics().Height.Ceil(); h > 0 {
+		return h
+	}
+	return 1
+}
+
+// maxScroll returns the largest vertical scroll offset.
+func (t *TextAreaComponent) maxScroll() int {
+	max := len(t.lines)*t.lineHeight() - t.inner.Dy()
+	if max < 0 {
+		return 0
+	}
+	return max
+}
+
+// applyTheme loads the font from the theme unless it is set in the options.
+func (t *TextAreaComponent) applyTheme(theme *Theme) {
+	t.theme = theme
+	fontName, fontSize := t.opts.Font, t.opts.FontSize
+	if fontName == "" {
+		fontName = theme.Typography.Font
+	}
+	if fontSize == 0 {
+		fontSize = theme.Typography.Body
+	}
+	if t.fontFace != nil && fontName == t.fontName && fontSize == t.fontSize {
+		return
+	}
+
+	ff, err := NewFontFace(fontName, fontSize)
+	if err != nil {
+		log.Fatalf("failed to load font: %s err=%s", fontName, err)
+	}
+	t.fontFace, t.fontName, t.fontSize = ff, fontName, fontSize
+	t.dirty = true
+}
+
+// wrap wraps the text again if it changed.
+func (t *TextAreaComponent) wrap() {
+	if t.dirty {
+		t.dirty = false
+		t.lines = wrapText(t.fontFace, t.editor.text, t.inner.Dx())
+	}
+}
+
+// lineAt returns the visual line containing the character index.
+func (t *TextAreaComponent) lineAt(index int) int {
+	t.wrap()
+
+	line := 0
+	for i := 0; i < len(t.lines); i++ {
+		if t.lines[i].start <= index {
+			line = i
+		}
+	}
+	return line
+}
+
+// moveLine moves the caret to the visual line nearest to the goal x coordinate.
+func (t *TextAreaComponent) moveLine(line int, extend bool) {
+	if t.goalX < 0 {
+		current := t.lines[t.lineAt(t.editor.caret)]
+		t.goalX = t.advance(current.start, t.editor.caret)
+	}
+
+	if line < 0 {
+		t.editor.moveTo(0, extend)
+		return
+	} else if line >= len(t.lines) {
+		t.editor.moveTo(len(t.editor.text), extend)
+		return
+	}
+	t.editor.moveTo(t.indexInLine(line, t.goalX), extend)
+}
+
+// advance returns the width of the text between the character indices.
+func (t *TextAreaComponent) advance(start, end int) int {
+	return font.MeasureString(t.fontFace, string(t.editor.text[start:end])).Round()
+}
+
+// indexInLine returns the character index in the visual line nearest to x.
+func (t *TextAreaComponent) indexInLine(line, x int) int {
+	l := t.lines[line]
+	prev := 0
+	for i := l.start + 1; i <= l.end; i++ {
+		next := t.advance(l.start, i)
+		if x < (prev+next)/2 {
+			return i - 1
+		}
+		prev = next
+	}
+	return l.end
+}
+
+// indexAt returns the character index nearest to the screen coordinates.
+func (t *TextAreaComponent) indexAt(x, y int) int {
+	line := (y - t.inner.Min.Y + t.scrollY) / t.lineHeight()
+	if y-t.inner.Min.Y+t.scrollY < 0 {
+		return 0
+	} else if line >= len(t.lines) {
+		return len(t.editor.text)
+	}
+	return t.indexInLine(line, x-t.inner.Min.X)
+}
+
+// changed rewraps the text and notifies the OnChange callback.
+func (t *TextAreaComponent) changed() {
+	t.dirty = true
+	if t.opts.OnChange != nil {
+		t.opts.OnChange(t.Text())
+	}
+}
+
+// wrapText breaks the text into visual lines no wider than the width. Lines are broken after whitespace when possible
+// and between characters when a single word is wider than the width.
+func wrapText(face font.Face, s []rune, width int) []textLine {
+	lines := make([]textLine, 0)
+	start, lastBreak := 0, -1
+	var lineWidth int
+	prev := rune(-1)
+
+	for i := 0; i <= len(s); i++ {
+		// hard line break
+		if i == len(s) || s[i] == '\n' {
+			lines = append(lines, textLine{start, i})
+			start, lastBreak, lineWidth, prev = i+1, -1, 0, -1
+			continue
+		}
+
+		adv := 0
+		if a, ok := face.GlyphAdvance(s[i]); ok {
+			adv = a.Round()
+		}
+		if prev >= 0 {
+			adv += face.Kern(prev, s[i]).Round()
+		}
+
+		// soft line break
+		if lineWidth+adv > width && i > start && s[i] != ' ' {
+			end := i
+			if lastBreak > start {
+				end = lastBreak
+			}
+			lines = append(lines, textLine{start, end})
+			start, lastBreak = end, -1
+			lineWidth = font.MeasureString(face, string(s[start:i])).Round()
+			adv = 0
+			if a, ok := face.GlyphAdvance(s[i]); ok {
+				adv = a.Round()
+			}
+		}
+
+		if s[i] == ' ' {
+			lastBreak = i + 1
+		}
+		lineWidth += adv
+		prev = s[i]
+	}
+	return lines
+}
+
+// Measure returns the size of the text area.
+func (t *TextAreaComponent) Measure(c Constraints) SizeHints {
+	return BoundsSizeHints(t.r)
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"image/color"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// clipboard is shared by all the text widgets for copy, cut and paste within the application.
+var clipboard string
+
+// editorColors returns the text, selection and caret colors of a text widget with the unset colors taken from the
+// theme. The caret defaults to the text color.
+func editorColors(ctx *DisplayContext, text, selection, caret color.Color) (color.Color, color.Color, color.Color) {
+	theme := ThemeFromContext(ctx.Context())
+	if text == nil {
+		text = theme.Palette.Text
+	}
+	if selection == nil {
+		selection = theme.Palette.Selection
+	}
+	if caret == nil {
+		caret = text
+	}
+	return text, selection, caret
+}
+
+// textEditor stores the text, caret and selection for the editable text widgets.
+type textEditor struct {
+	text      []rune
+	caret     int
+	anchor    int
+	maxLength int
+	multiline bool
+}
+
+// String returns the text.
+func (e *textEditor) String() string {
+	return string(e.text)
+}
+
+// SetText replaces the text and moves the caret to the end.
+func (e *textEditor) SetText(s string) {
+	e.text = []rune(s)
+	if e.maxLength > 0 && len(e.text) > e.maxLength {
+		e.text = e.text[:e.maxLength]
+	}
+	e.caret = len(e.text)
+	e.anchor = e.caret
+}
+
+// hasSelection returns true if text is selected.
+func (e *textEditor) hasSelection() bool {
+	return e.caret != e.anchor
+}
+
+// selection returns the start and end of the selection.
+func (e *textEditor) selection() (int, int) {
+	if e.anchor < e.caret {
+		return e.anchor, e.caret
+	}
+	return e.caret, e.anchor
+}
+
+// selectedText returns the selected text.
+func (e *textEditor) selectedText() string {
+	start, end := e.selection()
+	return string(e.text[start:end])
+}
+
+// selectAll selects all the text.
+func (e *textEditor) selectAll() {
+	e.anchor, e.caret = 0, len(e.text)
+}
+
+// moveTo moves the caret. If extend is true, the selection is extended to the new position.
+func (e *textEditor) moveTo(pos int, extend bool) {
+	if pos < 0 {
+		pos = 0
+	} else if pos > len(e.text) {
+		pos = len(e.text)
+	}
+	e.caret = pos
+	if !extend {
+		e.anchor = pos
+	}
+}
+
+// insert replaces the selection with the characters. Characters beyond the max length are dropped.
+func (e *textEditor) insert(chars []rune) bool {
+	filtered := make([]rune, 0, len(chars))
+	for _, r := range chars {
+		if (r == '\n' && e.multiline) || unicode.IsPrint(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	changed := e.deleteSelection()
+	if e.maxLength > 0 && len(e.text)+len(filtered) > e.maxLength {
+		filtered = filtered[:e.maxLength-len(e.text)]
+	}
+	if len(filtered) == 0 {
+		return changed
+	}
+
+	text := make([]rune, 0, len(e.text)+len(filtered))
+	text = append(text, e.text[:e.caret]...)
+	text = append(text, filtered...)
+	text = append(text, e.text[e.caret:]...)
+	e.text = text
+	e.moveTo(e.caret+len(filtered), false)
+	return true
+}
+
+// deleteSelection removes the selected text.
+func (e *textEditor) deleteSelection() bool {
+	if !e.hasSelection() {
+		return false
+	}
+	start, end := e.selection()
+	e.text = append(e.text[:start], e.text[end:]...)
+	e.moveTo(start, false)
+	return true
+}
+
+// deleteBackward removes the selection or the character or word before the caret.
+func (e *textEditor) deleteBackward(word bool) bool {
+	if e.hasSelection() {
+		return e.deleteSelection()
+	}
+	if e.caret == 0 {
+		return false
+	}
+
+	start := e.caret - 1
+	if word {
+		start = e.wordLeft(e.caret)
+	}
+	e.anchor = start
+	return e.deleteSelection()
+}
+
+// deleteForward removes the selection or the character or word after the caret.
+func (e *textEditor) deleteForward(word bool) bool {
+	if e.hasSelection() {
+		return e.deleteSelection()
+	}
+	if e.caret == len(e.text) {
+		return false
+	}
+
+	end := e.caret + 1
+	if word {
+		end = e.wordRight(e.caret)
+	}
+	e.anchor = end
+	return e.deleteSelection()
+}
+
+// wordLeft returns the start of the word before the position.
+func (e *textEditor) wordLeft(pos int) int {
+	for pos > 0 && !isWordRune(e.text[pos-1]) {
+		pos--
+	}
+	for pos > 0 && isWordRune(e.text[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordRight returns the end of the word after the position.
+func (e *textEditor) wordRight(pos int) int {
+	for pos < len(e.text) && !isWordRune(e.text[pos]) {
+		pos++
+	}
+	for pos < len(e.text) && isWordRune(e.text[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// selectWord selects the word containing the position.
+func (e *textEditor) selectWord(pos int) {
+	start, end := pos, pos
+	for start > 0 && isWordRune(e.text[start-1]) {
+		start--
+	}
+	for end < len(e.text) && isWordRune(e.text[end]) {
+		end++
+	}
+	e.anchor, e.caret = start, end
+}
+
+// lineStart returns the index of the first character on the line containing the position.
+func (e *textEditor) lineStart(pos int) int {
+	for pos > 0 && e.text[pos-1] != '\n' {
+		pos--
+	}
+	return pos
+}
+
+// lineEnd returns the index after the last character on the line containing the position.
+func (e *textEditor) lineEnd(pos int) int {
+	for pos < len(e.text) && e.text[pos] != '\n' {
+		pos++
+	}
+	return pos
+}
+
+// handleKey applies the editing keys common to all the text widgets. It returns whether the text changed and whether
+// the key was handled.
+func (e *textEditor) handleKey(evt KeyEvent, readOnly bool) (changed bool, handled bool) {
+	if evt.EventType == KeyReleaseEvent {
+		return false, false
+	}
+	extend := evt.Modifiers.Has(ModShift)
+	word := evt.Modifiers.Has(ModControl) || evt.Modifiers.Has(ModAlt)
+
+	switch evt.Key {
+	case ebiten.KeyLeft:
+		if e.hasSelection() && !extend {
+			start, _ := e.selection()
+			e.moveTo(start, false)
+		} else if word {
+			e.moveTo(e.wordLeft(e.caret), extend)
+		} else {
+			e.moveTo(e.caret-1, extend)
+		}
+	case ebiten.KeyRight:
+		if e.hasSelection() && !extend {
+			_, end := e.selection()
+			e.moveTo(end, false)
+		} else if word {
+			e.moveTo(e.wordRight(e.caret), extend)
+		} else {
+			e.moveTo(e.caret+1, extend)
+		}
+	case ebiten.KeyHome:
+		e.moveTo(e.lineStart(e.caret), extend)
+	case ebiten.KeyEnd:
+		e.moveTo(e.lineEnd(e.caret), extend)
+	case ebiten.KeyBackspace:
+		if readOnly {
+			return false, true
+		}
+		return e.deleteBackward(word), true
+	case ebiten.KeyDelete:
+		if readOnly {
+			return false, true
+		}
+		return e.deleteForward(word), true
+	default:
+		if !evt.Modifiers.Has(ModControl) {
+			return false, false
+		}
+
+		// shortcuts
+		switch evt.Key {
+		case ebiten.KeyA:
+			e.selectAll()
+		case ebiten.KeyC:
+			if e.hasSelection() {
+				clipboard = e.selectedText()
+			}
+		case ebiten.KeyX:
+			if e.hasSelection() {
+				clipboard = e.selectedText()
+				if !readOnly {
+					return e.deleteSelection(), true
+				}
+			}
+		case ebiten.KeyV:
+			if !readOnly {
+				return e.insert([]rune(clipboard)), true
+			}
+		default:
+			return false, false
+		}
+	}
+	return false, true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// caretBlinkTicks is the number of ticks the caret is shown or hidden while blinking.
+const caretBlinkTicks = 30
+
+// TextInputOptions contains the options for a text input.
+type TextInputOptions struct {
+	Font             string
+	FontSize         float64
+	TextColor        color.Color
+	PlaceholderColor color.Color
+	BackgroundColor  color.Color
+	SelectionColor   color.Color
+	CaretColor       color.Color
+	Border           Border
+	Padding          Quad
+	Placeholder      string
+	MaxLength        int
+
+	// OnChange is called whenever the text is edited.
+	OnChange func(text string)
+
+	// OnSubmit is called when Enter is pressed.
+	OnSubmit func(text string)
+}
+
+// TextInput creates an editable single-line text component. Unset fonts, colors and border colors follow the theme.
+func TextInput(r image.Rectangle, opts *TextInputOptions) *TextInputComponent {
+	text := DynamicText(&TextOptions{
+		Font:      opts.Font,
+		FontSize:  opts.FontSize,
+		TextColor: opts.TextColor,
+	})
+	placeholder := DynamicText(&TextOptions{
+		Font:      opts.Font,
+		FontSize:  opts.FontSize,
+		TextColor: opts.PlaceholderColor,
+	}).SetText(opts.Placeholder)
+	if opts.PlaceholderColor == nil {
+		placeholder.SetTextColor(DefaultTheme.Palette.TextMuted)
+	}
+
+	// interior of the input inside the border and padding
+	inner := image.Rect(
+		r.Min.X+opts.Border.Left.Width+opts.Padding.Left,
+		r.Min.Y+opts.Border.Top.Width+opts.Padding.Top,
+		r.Max.X-opts.Border.Right.Width-opts.Padding.Right,
+		r.Max.Y-opts.Border.Bottom.Width-opts.Padding.Bottom,
+	)
+	w, h := inner.Dx(), inner.Dy()
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	return &TextInputComponent{
+		r:     r,
+		inner: inner,
+		opts:  opts,
+		background: Rectangle(r, &RectangleOptions{
+			FillColor: opts.BackgroundColor,
+			Border:    opts.Border,
+		}),
+		text:        text,
+		placeholder: placeholder,
+		bufferImage: ebiten.NewImage(w, h),
+		editor:      &textEditor{maxLength: opts.MaxLength},
+	}
+}
+
+// TextInputComponent is an editable single-line text component.
+type TextInputComponent struct {
+	r           image.Rectangle
+	inner       image.Rectangle
+	opts        *TextInputOptions
+	background  Component
+	text        *DynamicTextComponent
+	placeholder *DynamicTextComponent
+	bufferImage *ebiten.Image
+	editor      *textEditor
+
+	focused  bool
+	dragging bool
+	blink    int
+	scrollX  int
+}
+
+// Text returns the current text.
+func (t *TextInputComponent) Text() string {
+	return t.editor.String()
+}
+
+// SetText replaces the text and moves the caret to the end. OnChange is not called.
+func (t *TextInputComponent) SetText(s string) *TextInputComponent {
+	t.editor.SetText(s)
+	return t
+}
+
+// Focused returns true if the input has keyboard focus.
+func (t *TextInputComponent) Focused() bool {
+	return t.focused
+}
+
+// OnFocus shows the caret.
+func (t *TextInputComponent) OnFocus() {
+	t.focused = true
+	t.blink = 0
+}
+
+// OnBlur hides the caret.
+func (t *TextInputComponent) OnBlur() {
+	t.focused = false
+	t.dragging = false
+}
+
+// Contains returns true if the point is within the input.
+func (t *TextInputComponent) Contains(x, y int) bool {
+	return image.Pt(x, y).In(t.r)
+}
+
+// OnKeyEvent edits the text.
+func (t *TextInputComponent) OnKeyEvent(evt KeyEvent) {
+	if evt.EventType == KeyReleaseEvent {
+		return
+	}
+	if evt.Key == ebiten.KeyEnter || evt.Key == ebiten.KeyKPEnter {
+		if evt.EventType == KeyPressEvent && t.opts.OnSubmit != nil {
+			t.opts.OnSubmit(t.Text())
+		}
+		return
+	}
+
+	changed, handled := t.editor.handleKey(evt, false)
+	if handled {
+		t.blink = 0
+	}
+	if changed {
+		t.changed()
+	}
+}
+
+// OnTextInput inserts the typed characters at the caret.
+func (t *TextInputComponent) OnTextInput(chars []rune) {
+	t.blink = 0
+	if t.editor.insert(chars) {
+		t.changed()
+	}
+}
+
+// OnMouseEvent moves the caret to the mouse position and starts selecting. Double clicks select a word and triple
+// clicks select all the text.
+func (t *TextInputComponent) OnMouseEvent(x, y int, evt MouseEvent) {
+	if evt.Button != ebiten.MouseButtonLeft {
+		return
+	}
+
+	switch evt.EventType {
+	case MousePressEvent:
+		if !t.Contains(x, y) {
+			return
+		}
+		t.dragging = true
+		t.blink = 0
+		t.editor.moveTo(t.indexAt(x), false)
+	case MouseDoubleClickEvent:
+		if t.Contains(x, y) {
+			t.dragging = false
+			t.editor.selectWord(t.indexAt(x))
+		}
+	case MouseTripleClickEvent:
+		if t.Contains(x, y) {
+			t.dragging = false
+			t.editor.selectAll()
+		}
+	case MouseReleaseEvent:
+		t.dragging = false
+	}
+}
+
+// OnMouseMove extends the selection while dragging.
+func (t *TextInputComponent) OnMouseMove(x, y int) {
+	if t.dragging {
+		t.editor.moveTo(t.indexAt(x), true)
+	}
+}
+
+// Update updates the text image and scrolls the caret into view.
+func (t *TextInputComponent) Update(ctx *UpdateContext) error {
+	t.blink++
+	if err := t.background.Update(ctx); err != nil {
+		return err
+	}
+
+	// the placeholder is muted text
+	theme := ThemeFromContext(ctx.Context())
+	if t.opts.PlaceholderColor == nil {
+		t.placeholder.SetTextColor(theme.Palette.TextMuted)
+	}
+
+	s := t.editor.String()
+	t.text.SetText(s)
+	if s != "" {
+		if err := t.text.Update(ctx); err != nil {
+			return err
+		}
+	}
+	if t.opts.Placeholder != "" {
+		if err := t.placeholder.Update(ctx); err != nil {
+			return err
+		}
+	}
+
+	// keep the caret visible
+	caretX := t.advance(t.editor.caret)
+	if caretX-t.scrollX > t.inner.Dx()-1 {
+		t.scrollX = caretX - t.inner.Dx() + 1
+	} else if caretX < t.scrollX {
+		t.scrollX = caretX
+	}
+	return nil
+}
+
+// Display renders the input.
+func (t *TextInputComponent) Display(ctx *DisplayContext) {
+	t.background.Display(ctx)
+
+	t.bufferImage.Fill(color.Transparent)
+	bufferCtx := NewDisplayContext(ctx.Context(), t.bufferImage)
+
+	face := t.text.FontFace()
+	m := face.Metrics()
+	_, h := t.bufferImage.Size()
+	baseline := (h + m.Ascent.Ceil() - m.Descent.Ceil()) / 2
+	top, bottom := baseline-m.Ascent.Ceil(), baseline+m.Descent.Ceil()
+	originX := -t.scrollX
+	_, selectionColor, caretColor := editorColors(ctx, t.opts.TextColor, t.opts.SelectionColor, t.opts.CaretColor)
+
+	// selection
+	if t.focused && t.editor.hasSelection() {
+		start, end := t.editor.selection()
+		vs, is := RectVertices(originX+t.advance(start), top, originX+t.advance(end), bottom, selectionColor)
+		bufferCtx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+	}
+
+	// text or placeholder
+	if len(t.editor.text) > 0 {
+		drawTextAt(bufferCtx, t.text, originX, baseline)
+	} else if t.opts.Placeholder != "" && !t.focused {
+		drawTextAt(bufferCtx, t.placeholder, 0, baseline)
+	}
+
+	// caret
+	if t.focused && (t.blink/caretBlinkTicks)%2 == 0 {
+		x := originX + t.advance(t.editor.caret)
+		vs, is := RectVertices(x, top, x+1, bottom, caretColor)
+		bufferCtx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(t.inner.Min.X), float64(t.inner.Min.Y))
+	ctx.DrawImage(t.bufferImage, op)
+}
+
+// advance returns the width of the text before the character index.
+func (t *TextInputComponent) advance(index int) int {
+	return font.MeasureString(t.text.FontFace(), string(t.editor.text[:index])).Round()
+}
+
+// indexAt returns the character index nearest to the screen x coordinate.
+func (t *TextInputComponent) indexAt(x int) int {
+	x = x - t.inner.Min.X + t.scrollX
+	prev := 0
+	for i := 1; i <= len(t.editor.text); i++ {
+		next := t.advance(i)
+		if x < (prev+next)/2 {
+			return i - 1
+		}
+		prev = next
+	}
+	return len(t.editor.text)
+}
+
+// changed notifies the OnChange callback.
+func (t *TextInputComponent) changed() {
+	if t.opts.OnChange != nil {
+		t.opts.OnChange(t.Text())
+	}
+}
+
+// drawTextAt renders the dynamic text with its origin at x on the baseline y.
+func drawTextAt(ctx *DisplayContext, d *DynamicTextComponent, x, y int) {
+	b := d.TextBounds()
+	d.SetPosition(float64(x+b.Min.X), float64(y+b.Min.Y))
+	d.Display(ctx)
+}
+
+// Measure returns the size of the input.
+func (t *TextInputComponent) Measure(c Constraints) SizeHints {
+	return BoundsSizeHints(t.r)
+}
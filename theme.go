@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"context"
+	"image/color"
+)
+
+// Palette stores the colors of a theme.
+type Palette struct {
+	Background color.Color
+	Surface    color.Color
+	Primary    color.Color
+	OnPrimary  color.Color
+	Text       color.Color
+	TextMuted  color.Color
+	Border     color.Color
+	Selection  color.Color
+	Error      color.Color
+}
+
+// TextStyle selects a size from the typography scale.
+type TextStyle int
+
+// These are the available text styles. The zero style is the body text.
+const (
+	TextBody TextStyle = iota
+	TextCaption
+	TextTitle
+	TextHeading
+)
+
+// Typography stores the font and the type scale of a theme. The font is found by name like NewFontFace; an empty
+// font uses the font with the shortest file name among the fonts installed on the system.
+type Typography struct {
+	Font    string
+	Caption float64
+	Body    float64
+	Title   float64
+	Heading float64
+}
+
+// Size returns the font size for the text style.
+func (t Typography) Size(style TextStyle) float64 {
+	switch style {
+	case TextCaption:
+		return t.Caption
+	case TextTitle:
+		return t.Title
+	case TextHeading:
+		return t.Heading
+	}
+	return t.Body
+}
+
+// Spacing stores the spacing scale of a theme in pixels.
+type Spacing struct {
+	XS, S, M, L, XL int
+}
+
+// StateStyle stores the colors of a widget in one state.
+type StateStyle struct {
+	Background color.Color
+	Foreground color.Color
+	Border     color.Color
+}
+
+// WidgetStyles stores the colors of interactive widgets in each state.
+type WidgetStyles struct {
+	Normal   StateStyle
+	Hover    StateStyle
+	Pressed  StateStyle
+	Focused  StateStyle
+	Disabled StateStyle
+}
+
+// Theme stores the default colors, fonts, spacing and borders used by components for the options which are not set.
+// The style sheet, which may be nil, styles the components created by Styled.
+// Themes are compared by pointer, so change a display's theme by setting a new theme rather than editing it.
+type Theme struct {
+	Name        string
+	Palette     Palette
+	Typography  Typography
+	Spacing     Spacing
+	BorderWidth int
+	Radius      int
+	Widget      WidgetStyles
+	Styles      *StyleSheet
+}
+
+// LightTheme creates a theme with dark text on a light background.
+func LightTheme() *Theme {
+	return &Theme{
+		Name: "light",
+		Palette: Palette{
+			Background: color.White,
+			Surface:    color.Gray{0xf2},
+			Primary:    color.RGBA{0x33, 0x99, 0xff, 0xff},
+			OnPrimary:  color.White,
+			Text:       color.Black,
+			TextMuted:  color.Gray{0x99},
+			Border:     color.Black,
+			Selection:  color.RGBA{0x33, 0x99, 0xff, 0x66},
+			Error:      color.RGBA{0xd3, 0x2f, 0x2f, 0xff},
+		},
+		Typography:  Typography{Caption: 10, Body: 12, Title: 18, Heading: 24},
+		Spacing:     Spacing{XS: 2, S: 4, M: 8, L: 16, XL: 32},
+		BorderWidth: 1,
+		Radius:      0,
+		Widget: WidgetStyles{
+			Normal:   StateStyle{Background: color.White, Foreground: color.Black, Border: color.Gray{0x80}},
+			Hover:    StateStyle{Background: color.Gray{0xe6}, Foreground: color.Black, Border: color.Gray{0x40}},
+			Pressed:  StateStyle{Background: color.RGBA{0x33, 0x99, 0xff, 0xff}, Foreground: color.White, Border: color.RGBA{0x1f, 0x6f, 0xbf, 0xff}},
+			Focused:  StateStyle{Background: color.White, Foreground: color.Black, Border: color.RGBA{0x33, 0x99, 0xff, 0xff}},
+			Disabled: StateStyle{Background: color.Gray{0xf2}, Foreground: color.Gray{0xb3}, Border: color.Gray{0xcc}},
+		},
+	}
+}
+
+// DarkTheme creates a theme with light text on a dark background.
+func DarkTheme() *Theme {
+	return &Theme{
+		Name: "dark",
+		Palette: Palette{
+			Background: color.Gray{0x1e},
+			Surface:    color.Gray{0x2d},
+			Primary:    color.RGBA{0x4d, 0xa6, 0xff, 0xff},
+			OnPrimary:  color.Black,
+			Text:       color.Gray{0xee},
+			TextMuted:  color.Gray{0x88},
+			Border:     color.Gray{0xcc},
+			Selection:  color.RGBA{0x4d, 0xa6, 0xff, 0x66},
+			Error:      color.RGBA{0xef, 0x53, 0x50, 0xff},
+		},
+		Typography:  Typography{Caption: 10, Body: 12, Title: 18, Heading: 24},
+		Spacing:     Spacing{XS: 2, S: 4, M: 8, L: 16, XL: 32},
+		BorderWidth: 1,
+		Radius:      0,
+		Widget: WidgetStyles{
+			Normal:   StateStyle{Background: color.Gray{0x2d}, Foreground: color.Gray{0xee}, Border: color.Gray{0x66}},
+			Hover:    StateStyle{Background: color.Gray{0x3c}, Foreground: color.White, Border: color.Gray{0x99}},
+			Pressed:  StateStyle{Background: color.RGBA{0x4d, 0xa6, 0xff, 0xff}, Foreground: color.Black, Border: color.RGBA{0x80, 0xc0, 0xff, 0xff}},
+			Focused:  StateStyle{Background: color.Gray{0x2d}, Foreground: color.Gray{0xee}, Border: color.RGBA{0x4d, 0xa6, 0xff, 0xff}},
+			Disabled: StateStyle{Background: color.Gray{0x26}, Foreground: color.Gray{0x5c}, Border: color.Gray{0x40}},
+		},
+	}
+}
+
+// DefaultTheme is used by components which are not displayed with a themed context. It matches the defaults used
+// before themes were introduced.
+var DefaultTheme = LightTheme()
+
+const contextKeyTheme contextKey = "theme"
+
+// WithTheme returns a context which carries the theme.
+func WithTheme(ctx context.Context, t *Theme) context.Context {
+	return context.WithValue(ctx, contextKeyTheme, t)
+}
+
+// ThemeFromContext returns the theme carried by the context or DefaultTheme if there is none.
+func ThemeFromContext(ctx context.Context) *Theme {
+	if ctx != nil {
+		if t, ok := ctx.Value(contextKeyTheme).(*Theme); ok && t != nil {
+			return t
+		}
+	}
+	return DefaultTheme
+}
+
+// Themed creates a component which is built from the theme and rebuilt during Update whenever the theme of the
+// display changes. The component is first built from DefaultTheme so it can be measured before it is displayed.
+func Themed(build func(t *Theme) Component) Component {
+	return &themedComponent{build: build, theme: DefaultTheme, component: build(DefaultTheme)}
+}
+
+type themedComponent struct {
+	build     func(t *Theme) Component
+	theme     *Theme
+	component Component
+}
+
+// Update rebuilds the component if the theme changed and updates it.
+func (c *themedComponent) Update(ctx *UpdateContext) error {
+	if t := ThemeFromContext(ctx.Context()); t != c.theme {
+		c.theme = t
+		c.component = c.build(t)
+	}
+	return c.component.Update(ctx)
+}
+
+// Display renders the component.
+func (c *themedComponent) Display(ctx *DisplayContext) {
+	c.component.Display(ctx)
+}
+
+// Measure returns the size of the component.
+func (c *themedComponent) Measure(cons Constraints) SizeHints {
+	return Measure(c.component, cons)
+}
+
+// themedBorder returns the border with the unset colors of the visible sides taken from the theme.
+func themedBorder(b Border, t *Theme) Border {
+	sides := []*Stroke{&b.Left, &b.Right, &b.Top, &b.Bottom}
+	for i := 0; i < len(sides); i++ {
+		if sides[i].Width > 0 && sides[i].Color == nil {
+			sides[i].Color = t.Palette.Border
+		}
+	}
+	return b
+}
+
+// needsThemedBorder returns true if a visible side of the border has no color.
+func needsThemedBorder(b Border) bool {
+	sides := []Stroke{b.Left, b.Right, b.Top, b.Bottom}
+	for i := 0; i < len(sides); i++ {
+		if sides[i].Width > 0 && sides[i].Color == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"time"
+)
+
+// These are the defaults for tooltips.
+const (
+	DefaultTooltipDelay  = 500 * time.Millisecond
+	DefaultTooltipOffset = 16
+)
+
+// TooltipOptions contains the options for a tooltip. Unset fonts and colors follow the theme, with the background
+// taken from the surface color. A nil Padding uses the small spacing of the theme. The tooltip is shown once the mouse
+// has rested over the component for the Delay and is placed Offset pixels below the cursor.
+//
+// Bounds sets the area which shows the tooltip, relative to the position of the node, for components which are not
+// Hittable.
+type TooltipOptions struct {
+	Font            string
+	FontSize        float64
+	TextColor       color.Color
+	BackgroundColor color.Color
+	Padding         *Quad
+	Delay           time.Duration
+	Offset          int
+	Bounds          image.Rectangle
+}
+
+// WithTooltip returns a node for the component which shows the text in a tooltip while the mouse rests over the
+// component. The tooltip is shown in the tooltip layer near the cursor, kept within the window and hidden when the
+// mouse moves away or a button is pressed. The node should be added to a display in place of the component.
+func WithTooltip(c Component, text string, opts *TooltipOptions) *Node {
+	delay := opts.Delay
+	if delay <= 0 {
+		delay = DefaultTooltipDelay
+	}
+	offset := opts.Offset
+	if offset <= 0 {
+		offset = DefaultTooltipOffset
+	}
+
+	t := &tooltip{delay: delay, offset: offset}
+	t.text = Themed(func(theme *Theme) Component {
+		bg := opts.BackgroundColor
+		if bg == nil {
+			bg = theme.Palette.Surface
+		}
+		padding := Quad{theme.Spacing.S, theme.Spacing.S, theme.Spacing.S, theme.Spacing.S}
+		if opts.Padding != nil {
+			padding = *opts.Padding
+		}
+		return DynamicText(&TextOptions{
+			Font:            opts.Font,
+			FontSize:        opts.FontSize,
+			TextColor:       opts.TextColor,
+			BackgroundColor: bg,
+			Padding:         padding,
+		}).SetText(text)
+	})
+
+	// the controller is a child without bounds so it is updated with the component but never hit
+	n := NewNode(c).SetBounds(opts.Bounds).AddMouseListener(t)
+	n.Add(NewNode(t))
+	return n
+}
+
+// tooltip shows a tooltip for the node it listens to.
+type tooltip struct {
+	text   Component
+	delay  time.Duration
+	offset int
+
+	hover  bool
+	moved  bool
+	since  time.Duration
+	cursor image.Point
+	popup  *Popup
+}
+
+// HandleMouseEvent tracks the mouse over the node. The events are not consumed.
+func (t *tooltip) HandleMouseEvent(evt *PointerEvent) bool {
+	switch evt.EventType {
+	case MouseEnterEvent, MouseMoveEvent:
+		t.hover = true
+		t.moved = true
+		t.cursor = image.Pt(evt.X, evt.Y)
+	case MouseLeaveEvent:
+		t.hover = false
+		t.hide()
+	case MousePressEvent, MouseWheelEvent:
+		t.hide()
+	}
+	return false
+}
+
+// Update shows the tooltip once the mouse has rested for the delay.
+func (t *tooltip) Update(ctx *UpdateContext) error {
+	if t.moved {
+		t.moved = false
+		t.since = ctx.Elapsed()
+	}
+	if !t.hover || t.popup != nil || ctx.Overlays() == nil || ctx.Elapsed()-t.since < t.delay {
+		return nil
+	}
+
+	// the cursor is the anchor so the tooltip flips above it near the bottom of the window; the popup may also be closed
+	// by the overlays, such as by CloseAll, so it is forgotten whenever it closes
+	t.popup = ctx.Overlays().Show(t.text, &PopupOptions{
+		Layer:   TooltipLayer,
+		Anchor:  image.Rectangle{t.cursor, t.cursor},
+		Offset:  t.offset,
+		OnClose: func() { t.popup = nil },
+	})
+	return nil
+}
+
+// Display is a no-op. The tooltip is drawn in the tooltip layer.
+func (t *tooltip) Display(ctx *DisplayContext) {}
+
+// hide closes the tooltip. It is shown again after the mouse rests.
+func (t *tooltip) hide() {
+	if t.popup != nil {
+		t.popup.Close()
+	}
+}
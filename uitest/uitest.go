@@ -0,0 +1,177 @@
+// Package uitest renders components off screen and compares them to golden images.
+//
+// Tests using the package must run the off screen game loop from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		uitest.Main(m)
+//	}
+//
+// Golden images are stored as PNG files in the testdata directory and are regenerated with the -update flag.
+package uitest
+
+import (
+	"context"
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eliquious/ui"
+)
+
+var update = flag.Bool("update", false, "update golden images")
+
+// Main runs the tests while the off screen game loop is running and exits with the result.
+func Main(m *testing.M) {
+	os.Exit(ui.RunOffscreen(m.Run))
+}
+
+// Options stores the options for rendering and comparing a component. A zero size is 256x256, a nil Background is
+// transparent and zero Frames renders a single frame. Pixels match when every channel is within Tolerance. A nil Input
+// renders without any mouse or keyboard input.
+type Options struct {
+	Width, Height int
+	Background    color.Color
+	Frames        int
+	Tolerance     uint8
+	Dir           string
+	Input         *ui.ScriptedInput
+}
+
+// defaults returns a copy of the options with the defaults applied.
+func defaults(opts *Options) Options {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Width == 0 {
+		o.Width = 256
+	}
+	if o.Height == 0 {
+		o.Height = 256
+	}
+	if o.Frames == 0 {
+		o.Frames = 1
+	}
+	if o.Dir == "" {
+		o.Dir = "testdata"
+	}
+	if o.Input == nil {
+		o.Input = ui.NewScriptedInput()
+	}
+	return o
+}
+
+// Render renders the component off screen and returns the last frame. The display uses a virtual clock and its own
+// scripted input, so animated components render the same frame on every run and the mouse and keyboard are ignored.
+func Render(c ui.Component, opts *Options) (*image.RGBA, error) {
+	o := defaults(opts)
+	display := ui.New(context.Background(), &ui.DisplaySettings{
+		Title:           "uitest",
+		Width:           o.Width,
+		Height:          o.Height,
+		BackgroundColor: o.Background,
+		Clock:           ui.NewVirtualClock(0),
+		Input:           o.Input,
+	})
+	display.Add(c)
+	return display.Render(o.Frames)
+}
+
+// Golden renders the component and compares it to the golden image with the name.
+func Golden(t testing.TB, name string, c ui.Component, opts *Options) {
+	t.Helper()
+	img, err := Render(c, opts)
+	if err != nil {
+		t.Fatalf("failed to render %s: %s", name, err)
+	}
+	AssertGolden(t, name, img, opts)
+}
+
+// AssertGolden compares the image to the golden image with the name. With the -update flag the golden image is
+// written instead. On failure the actual image and a diff image are written next to the golden image.
+func AssertGolden(t testing.TB, name string, img image.Image, opts *Options) {
+	t.Helper()
+	o := defaults(opts)
+	path := filepath.Join(o.Dir, name+".png")
+
+	if *update {
+		if err := ui.SavePNG(path, img); err != nil {
+			t.Fatalf("failed to update golden image: %s", err)
+		}
+		return
+	}
+
+	golden, err := readPNG(path)
+	if err != nil {
+		t.Fatalf("failed to read golden image (run with -update to create it): %s", err)
+	}
+
+	diff, count := Compare(golden, img, o.Tolerance)
+	if count == 0 {
+		return
+	}
+
+	actualPath := filepath.Join(o.Dir, name+".actual.png")
+	diffPath := filepath.Join(o.Dir, name+".diff.png")
+	if err := ui.SavePNG(actualPath, img); err != nil {
+		t.Errorf("failed to write actual image: %s", err)
+	}
+	if err := ui.SavePNG(diffPath, diff); err != nil {
+		t.Errorf("failed to write diff image: %s", err)
+	}
+	t.Errorf("%s: %d pixels differ from the golden image; actual=%s diff=%s", name, count, actualPath, diffPath)
+}
+
+// Compare compares the images pixel by pixel and returns a diff image and the number of pixels which differ by more
+// than the tolerance in any channel. The diff image shows the expected image faded with differing pixels in red.
+// Pixels outside of either image always differ.
+func Compare(expected, actual image.Image, tolerance uint8) (*image.RGBA, int) {
+	bounds := expected.Bounds().Union(actual.Bounds())
+	diff := image.NewRGBA(bounds)
+	count := 0
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			p := image.Pt(x, y)
+			if !p.In(expected.Bounds()) || !p.In(actual.Bounds()) {
+				diff.Set(x, y, color.RGBA{255, 0, 0, 255})
+				count++
+				continue
+			}
+
+			e := color.RGBAModel.Convert(expected.At(x, y)).(color.RGBA)
+			a := color.RGBAModel.Convert(actual.At(x, y)).(color.RGBA)
+			if within(e.R, a.R, tolerance) && within(e.G, a.G, tolerance) && within(e.B, a.B, tolerance) && within(e.A, a.A, tolerance) {
+				gray := uint8((uint16(e.R) + uint16(e.G) + uint16(e.B)) / 3)
+				gray = 255 - (255-gray)/4
+				diff.Set(x, y, color.RGBA{gray, gray, gray, 255})
+				continue
+			}
+			diff.Set(x, y, color.RGBA{255, 0, 0, 255})
+			count++
+		}
+	}
+	return diff, count
+}
+
+// within returns true if the channels differ by no more than the tolerance.
+func within(a, b, tolerance uint8) bool {
+	if a > b {
+		return a-b <= tolerance
+	}
+	return b-a <= tolerance
+}
+
+// readPNG reads a PNG image from the file.
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
@@ -0,0 +1,126 @@
+package uitest_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/eliquious/ui"
+	"github.com/eliquious/ui/uitest"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestMain(m *testing.M) {
+	uitest.Main(m)
+}
+
+func TestCompare(t *testing.T) {
+	expected := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	actual := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	actual.Set(1, 1, color.RGBA{2, 0, 0, 0})
+	actual.Set(2, 2, color.RGBA{0, 0, 9, 0})
+
+	if _, count := uitest.Compare(expected, actual, 2); count != 1 {
+		t.Errorf("expected 1 differing pixel within the tolerance; got %d", count)
+	}
+	if _, count := uitest.Compare(expected, actual, 0); count != 2 {
+		t.Errorf("expected 2 differing pixels without a tolerance; got %d", count)
+	}
+
+	// pixels outside of either image differ
+	if _, count := uitest.Compare(expected, image.NewRGBA(image.Rect(0, 0, 4, 3)), 0); count != 4 {
+		t.Errorf("expected the missing row to differ; got %d", count)
+	}
+}
+
+func TestLineVertices(t *testing.T) {
+	vs, is := ui.LineVertices(10, 20, 110, 20, ui.Stroke{Color: color.White, Width: 4})
+	if len(vs) != 4 || len(is) != 6 {
+		t.Fatalf("expected a quad; got %d vertices and %d indices", len(vs), len(is))
+	}
+
+	// a horizontal line is offset vertically by half the stroke on each side
+	for i := 0; i < len(vs); i++ {
+		if y := vs[i].DstY; math.Abs(float64(y-18)) > 1e-4 && math.Abs(float64(y-22)) > 1e-4 {
+			t.Errorf("vertex %d: expected y of 18 or 22; got %f", i, y)
+		}
+		if x := vs[i].DstX; math.Abs(float64(x-10)) > 1e-4 && math.Abs(float64(x-110)) > 1e-4 {
+			t.Errorf("vertex %d: expected x of 10 or 110; got %f", i, x)
+		}
+	}
+}
+
+func TestRectVertices(t *testing.T) {
+	vs, is := ui.RectVertices(4, 8, 20, 40, color.RGBA{0xff, 0x80, 0x00, 0xff})
+	if len(vs) != 4 || len(is) != 6 {
+		t.Fatalf("expected a quad; got %d vertices and %d indices", len(vs), len(is))
+	}
+
+	var bounds image.Rectangle
+	for i := 0; i < len(vs); i++ {
+		bounds = bounds.Union(image.Rect(int(vs[i].DstX), int(vs[i].DstY), int(vs[i].DstX)+1, int(vs[i].DstY)+1))
+		if vs[i].ColorR != 1 || vs[i].ColorB != 0 || vs[i].ColorA != 1 {
+			t.Errorf("vertex %d: unexpected color %f %f %f %f", i, vs[i].ColorR, vs[i].ColorG, vs[i].ColorB, vs[i].ColorA)
+		}
+	}
+	if expected := image.Rect(4, 8, 21, 41); bounds != expected {
+		t.Errorf("expected the vertices to span %s; got %s", expected, bounds)
+	}
+}
+
+var (
+	white = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	blue  = color.RGBA{0x33, 0x99, 0xff, 0xff}
+)
+
+// assertPixel compares the pixel to a single pixel image of the color, within a tolerance for antialiasing.
+func assertPixel(t *testing.T, img *image.RGBA, x, y int, expected color.RGBA) {
+	t.Helper()
+	want := image.NewRGBA(image.Rect(x, y, x+1, y+1))
+	want.SetRGBA(x, y, expected)
+	if _, count := uitest.Compare(want, img.SubImage(want.Rect), 2); count != 0 {
+		t.Errorf("pixel %d,%d: expected %v; got %v", x, y, expected, img.RGBAAt(x, y))
+	}
+}
+
+func TestRenderLine(t *testing.T) {
+	line := ui.VertexLine(ui.Stroke{Color: color.Black, Width: 3}).SetPoints(8, 8, 120, 88)
+	img, err := uitest.Render(line, &uitest.Options{Width: 128, Height: 96, Background: color.White})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPixel(t, img, 64, 48, color.RGBA{0x00, 0x00, 0x00, 0xff})
+	assertPixel(t, img, 120, 8, white)
+	assertPixel(t, img, 8, 88, white)
+}
+
+func TestRenderRectVertices(t *testing.T) {
+	rect := ui.SimpleComponent(func(ctx *ui.DisplayContext) {
+		vs, is := ui.RectVertices(16, 16, 112, 80, blue)
+		ctx.DrawTriangles(vs, is, &ebiten.DrawTrianglesOptions{})
+	})
+	img, err := uitest.Render(rect, &uitest.Options{Width: 128, Height: 96, Background: color.White})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPixel(t, img, 8, 8, white)
+	assertPixel(t, img, 16, 16, blue)
+	assertPixel(t, img, 64, 48, blue)
+	assertPixel(t, img, 120, 88, white)
+}
+
+func TestRenderDynamicCircle(t *testing.T) {
+	circle := ui.DynamicCircle(&ui.CircleOptions{
+		FillColor: blue,
+		Stroke:    ui.Stroke{Color: color.Black, Width: 3},
+	})
+	circle.SetPosition(64, 64)
+	circle.SetRadius(48)
+	img, err := uitest.Render(circle, &uitest.Options{Width: 128, Height: 128, Background: color.White})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertPixel(t, img, 4, 4, white)
+	assertPixel(t, img, 64, 64, blue)
+}